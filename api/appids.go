@@ -0,0 +1,127 @@
+package api
+
+import (
+	"errors"
+	"sync"
+)
+
+// A Rotation defines how an AppIDPool chooses the AppID for each query.
+type Rotation int
+
+const (
+	// Use each AppID in turn
+	RoundRobin Rotation = iota
+
+	// Use the AppID that was used least recently
+	LeastRecentlyUsed
+)
+
+// MaxAppIDFailures is the number of consecutive failed queries after which
+// an AppIDPool stops choosing an AppID, as long as others are still healthy.
+const MaxAppIDFailures = 3
+
+// An AppIDPool spreads queries across several AppIDs, for teams with several
+// registered applications. It tracks failures per AppID and skips AppIDs that
+// keep failing. An AppIDPool is safe for concurrent use.
+type AppIDPool struct {
+	mu       sync.Mutex
+	ids      []string
+	rotation Rotation
+	next     int
+	clock    int
+	lastUsed []int
+	failures []int
+}
+
+// NewAppIDPool returns a pool that rotates among the given AppIDs.
+func NewAppIDPool(rotation Rotation, ids ...string) *AppIDPool {
+	return &AppIDPool{
+		ids:      append([]string(nil), ids...),
+		rotation: rotation,
+		lastUsed: make([]int, len(ids)),
+		failures: make([]int, len(ids)),
+	}
+}
+
+// Next returns the AppID to use for the next query, or an empty string if the
+// pool is empty.
+func (p *AppIDPool) Next() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.ids) == 0 {
+		return ""
+	}
+
+	healthy := func(i int) bool { return p.failures[i] < MaxAppIDFailures }
+	anyHealthy := false
+	for i := range p.ids {
+		anyHealthy = anyHealthy || healthy(i)
+	}
+
+	choice := -1
+	switch p.rotation {
+	case LeastRecentlyUsed:
+		for i := range p.ids {
+			if (!anyHealthy || healthy(i)) && (choice < 0 || p.lastUsed[i] < p.lastUsed[choice]) {
+				choice = i
+			}
+		}
+	default:
+		for n := 0; n < len(p.ids); n++ {
+			i := (p.next + n) % len(p.ids)
+			if !anyHealthy || healthy(i) {
+				choice = i
+				break
+			}
+		}
+		p.next = (choice + 1) % len(p.ids)
+	}
+
+	p.clock++
+	p.lastUsed[choice] = p.clock
+	return p.ids[choice]
+}
+
+// Failures returns the number of consecutive failed queries made with the
+// AppID.
+func (p *AppIDPool) Failures(id string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.ids {
+		if p.ids[i] == id {
+			return p.failures[i]
+		}
+	}
+	return 0
+}
+
+// Report records the outcome of a query made with the AppID. Only errors the
+// AppID caused (an *Error saying the AppID is invalid, missing, or over its
+// quota) count as failures; other errors, like timeouts, cancellations,
+// network failures, and malformed responses, say nothing about the AppID and
+// are ignored. Clients report their own queries; it only needs to be called
+// directly for queries made outside the package.
+func (p *AppIDPool) Report(id string, err error) {
+	if err != nil && !appIDFailure(err) {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.ids {
+		if p.ids[i] == id {
+			if err != nil {
+				p.failures[i]++
+			} else {
+				p.failures[i] = 0
+			}
+		}
+	}
+}
+
+// appIDFailure reports whether err is an API error caused by the AppID.
+// Wolfram Alpha reports invalid and over-quota AppIDs with code 1, and
+// missing AppIDs with code 2.
+func appIDFailure(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && (apiErr.Code == 1 || apiErr.Code == 2)
+}
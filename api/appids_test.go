@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAppIDPool_RoundRobin(t *testing.T) {
+	pool := NewAppIDPool(RoundRobin, "A", "B", "C")
+	assert.Equal(t, []string{"A", "B", "C", "A"}, []string{pool.Next(), pool.Next(), pool.Next(), pool.Next()})
+
+	fail := &Error{Code: 1, Message: "Invalid appid"}
+	for i := 0; i < MaxAppIDFailures; i++ {
+		pool.Report("B", fail)
+	}
+	assert.Equal(t, MaxAppIDFailures, pool.Failures("B"))
+	assert.Equal(t, []string{"C", "A", "C"}, []string{pool.Next(), pool.Next(), pool.Next()})
+
+	pool.Report("B", nil)
+	assert.Equal(t, 0, pool.Failures("B"))
+	assert.Equal(t, []string{"A", "B"}, []string{pool.Next(), pool.Next()})
+}
+
+func TestAppIDPool_LeastRecentlyUsed(t *testing.T) {
+	pool := NewAppIDPool(LeastRecentlyUsed, "A", "B", "C")
+	assert.Equal(t, []string{"A", "B", "C", "A"}, []string{pool.Next(), pool.Next(), pool.Next(), pool.Next()})
+
+	fail := &Error{Code: 1, Message: "Invalid appid"}
+	for _, id := range []string{"A", "B", "C"} {
+		for i := 0; i < MaxAppIDFailures; i++ {
+			pool.Report(id, fail)
+		}
+	}
+	// With every AppID failing, the pool falls back to using all of them.
+	assert.Equal(t, []string{"B", "C", "A"}, []string{pool.Next(), pool.Next(), pool.Next()})
+}
+
+func TestAppIDPool_empty(t *testing.T) {
+	assert.Equal(t, "", NewAppIDPool(RoundRobin).Next())
+}
+
+func TestAppIDPool_Report(t *testing.T) {
+	pool := NewAppIDPool(RoundRobin, "A")
+	pool.Report("A", &Error{Code: 1, Message: "Invalid appid"})
+	pool.Report("A", &Error{Code: 2, Message: "Appid missing"})
+	assert.Equal(t, 2, pool.Failures("A"))
+
+	for _, err := range []error{
+		context.Canceled,
+		&TimeoutError{Err: context.DeadlineExceeded},
+		errors.New("api: unexpected response status 502 Bad Gateway"),
+		&ParseError{Err: errors.New("XML syntax error")},
+		&Error{Code: 1000, Message: "Not processed"},
+	} {
+		pool.Report("A", err)
+	}
+	assert.Equal(t, 2, pool.Failures("A"))
+}
+
+func TestNewAppIDPool_copies(t *testing.T) {
+	ids := []string{"A", "B"}
+	pool := NewAppIDPool(RoundRobin, ids...)
+	ids[0] = "C"
+	assert.Equal(t, "A", pool.Next())
+}
+
+func TestClient_Query_AppIDs(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("appid")
+		ids = append(ids, id)
+		if id == "BAD" {
+			w.Write([]byte(`<queryresult success="false" error="true"><error><code>1</code><msg>Invalid appid</msg></error></queryresult>`))
+			return
+		}
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+
+	pool := NewAppIDPool(RoundRobin, "GOOD", "BAD")
+	c := Client{AppID: "IGNORED", AppIDs: pool, Endpoint: server.URL}
+	for i := 0; i < 4; i++ {
+		c.Query("pi")
+	}
+	assert.Equal(t, []string{"GOOD", "BAD", "GOOD", "BAD"}, ids)
+	assert.Equal(t, 0, pool.Failures("GOOD"))
+	assert.Equal(t, 2, pool.Failures("BAD"))
+}
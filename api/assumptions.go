@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// An AssumptionChange describes how an assumption differs between two
+// results, as reported by AssumptionDiff.
+type AssumptionChange struct {
+	// The assumption type
+	Type string
+
+	// The word or phrase to which the assumption is applied
+	Word string
+
+	// The value assumed in the first result, or nil if the assumption was added
+	Before *AssumptionValue
+
+	// The value assumed in the second result, or nil if the assumption was
+	// removed
+	After *AssumptionValue
+}
+
+// AssumptionDiff reports the assumptions that were added, removed, or changed
+// between results a and b, such as when rewording a query flips what Wolfram
+// Alpha assumes a word means. Assumptions are matched by type and word, and an
+// assumption has changed when its assumed (first) value differs. Changes are
+// ordered as the assumptions appear in a, followed by assumptions only in b.
+func AssumptionDiff(a, b Result) []AssumptionChange {
+	type key struct{ typ, word string }
+	after := make(map[key]Assumption)
+	for _, assum := range b.Assumptions {
+		after[key{assum.Type, assum.Word}] = assum
+	}
+
+	var changes []AssumptionChange
+	seen := make(map[key]bool)
+	for _, assum := range a.Assumptions {
+		k := key{assum.Type, assum.Word}
+		seen[k] = true
+		before, now := assum.assumed(), after[k].assumed()
+		if before == nil && now == nil || before != nil && now != nil && before.Name == now.Name {
+			continue
+		}
+		changes = append(changes, AssumptionChange{Type: assum.Type, Word: assum.Word, Before: before, After: now})
+	}
+	for _, assum := range b.Assumptions {
+		k := key{assum.Type, assum.Word}
+		if now := assum.assumed(); !seen[k] && now != nil {
+			seen[k] = true
+			changes = append(changes, AssumptionChange{Type: assum.Type, Word: assum.Word, After: now})
+		}
+	}
+	return changes
+}
+
+// String returns a description of the change suitable for display to the
+// user, like `now assuming "mercury" is a planet, not a chemical element`.
+func (c AssumptionChange) String() string {
+	word := strconv.Quote(c.Word)
+	switch {
+	case c.Before == nil && c.After == nil:
+		return "no change in assuming " + word
+	case c.Before == nil:
+		return "now assuming " + word + " is " + c.After.Description
+	case c.After == nil:
+		return "no longer assuming " + word + " is " + c.Before.Description
+	}
+	return "now assuming " + word + " is " + c.After.Description + ", not " + c.Before.Description
+}
+
+// assumed returns the assumption's assumed value, or nil if it has none.
+func (a Assumption) assumed() *AssumptionValue {
+	if len(a.Values) == 0 {
+		return nil
+	}
+	return &a.Values[0]
+}
+
+// An Interpretation is one way of reading an ambiguous query: a choice of
+// value for each of its Clash and MultiClash assumptions.
+type Interpretation struct {
+	// The word or phrase each value applies to
+	Words []string
+
+	// The chosen value for each ambiguous assumption, in order
+	Values []AssumptionValue
+}
+
+// Interpretations expands the result's Clash and MultiClash assumptions (which
+// occur when words in the query have several possible meanings) into every
+// combination of their values, for presenting a disambiguation picker. The
+// first interpretation is the one Wolfram Alpha assumed. The number of
+// interpretations is the product of the assumptions' value counts, so UIs may
+// want to show only the first few. It returns nil if the query isn't
+// ambiguous.
+func (r Result) Interpretations() []Interpretation {
+	var clashes []Assumption
+	for _, assum := range r.Assumptions {
+		if (assum.Type == "Clash" || assum.Type == "MultiClash") && len(assum.Values) > 0 {
+			clashes = append(clashes, assum)
+		}
+	}
+	if len(clashes) == 0 {
+		return nil
+	}
+
+	interps := []Interpretation{{}}
+	for _, assum := range clashes {
+		next := make([]Interpretation, 0, len(interps)*len(assum.Values))
+		for _, interp := range interps {
+			for _, value := range assum.Values {
+				word := value.Word
+				if word == "" {
+					word = assum.Word
+				}
+				next = append(next, Interpretation{
+					Words:  append(interp.Words[:len(interp.Words):len(interp.Words)], word),
+					Values: append(interp.Values[:len(interp.Values):len(interp.Values)], value),
+				})
+			}
+		}
+		interps = next
+	}
+	return interps
+}
+
+// Assumptions returns the assumption inputs that request the interpretation,
+// ready to send as the query's "assumption" parameters.
+func (i Interpretation) Assumptions() []string {
+	inputs := make([]string, len(i.Values))
+	for j, value := range i.Values {
+		inputs[j] = value.Input
+	}
+	return inputs
+}
+
+// String returns a description of the interpretation suitable for display to
+// the user, like `"mercury" as a planet, "venus" as a Roman god`.
+func (i Interpretation) String() string {
+	parts := make([]string, len(i.Values))
+	for j, value := range i.Values {
+		parts[j] = strconv.Quote(i.Words[j]) + " as " + value.Description
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ApplyAssumption re-issues the query that produced r with the assumption
+// value applied, such as after a user picks a different meaning of a word. It
+// is equivalent to ApplyAssumptionContext with a background context.
+func (c Client) ApplyAssumption(r Result, value AssumptionValue) (Result, error) {
+	return c.ApplyAssumptionContext(context.Background(), r, value)
+}
+
+// ApplyAssumptionContext re-issues the query that produced r (see
+// Result.Input) with the assumption value applied, in addition to any
+// assumptions in the client's Extra. It returns an error if r didn't come
+// from a query.
+func (c Client) ApplyAssumptionContext(ctx context.Context, r Result, value AssumptionValue) (Result, error) {
+	if r.Input == "" {
+		return Result{}, errors.New("api: result has no input to re-query")
+	}
+	return c.QueryWithOptionsContext(ctx, r.Input, WithAssumptions(value.Input))
+}
+
+// origin records how a result was queried, so it can be queried again.
+type origin struct {
+	client Client
+	opts   []QueryOption
+}
+
+// AssumptionChoices yields the alternatives to the assumptions Wolfram Alpha
+// made for the result (every value but the assumed one), each with a
+// description suitable for display to the user, like `"mercury" as a
+// planet`, and a function that re-issues the query with that alternative
+// applied. The functions use the client and per-query options that produced
+// the result, so UIs can offer the alternatives without handling assumption
+// inputs themselves. It yields nothing for results that didn't come from a
+// query.
+func (r Result) AssumptionChoices() iter.Seq2[string, func(context.Context) (Result, error)] {
+	return func(yield func(string, func(context.Context) (Result, error)) bool) {
+		if r.origin == nil {
+			return
+		}
+		from, input := *r.origin, r.Input
+		for _, assum := range r.Assumptions {
+			for _, value := range assum.Values[min(1, len(assum.Values)):] {
+				word := value.Word
+				if word == "" {
+					word = assum.Word
+				}
+				apply := func(ctx context.Context) (Result, error) {
+					opts := append(from.opts[:len(from.opts):len(from.opts)], WithAssumptions(value.Input))
+					return from.client.QueryWithOptionsContext(ctx, input, opts...)
+				}
+				if !yield(strconv.Quote(word)+" as "+value.Description, apply) {
+					return
+				}
+			}
+		}
+	}
+}
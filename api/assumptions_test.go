@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"testing"
+)
+
+func TestAssumptionDiff(t *testing.T) {
+	planet := AssumptionValue{Name: "Planet", Description: "a planet", Input: "*C.mercury-_*Planet-"}
+	element := AssumptionValue{Name: "Element", Description: "a chemical element", Input: "*C.mercury-_*Element-"}
+	god := AssumptionValue{Name: "MythologicalFigure", Description: "a mythological figure", Input: "*C.mercury-_*MythologicalFigure-"}
+	a := Result{Assumptions: []Assumption{
+		{Type: "Clash", Word: "mercury", Values: []AssumptionValue{element, planet, god}},
+		{Type: "Unit", Word: "m", Values: []AssumptionValue{{Name: "Meters", Description: "meters"}}},
+		{Type: "SubCategory", Word: "orbit", Values: []AssumptionValue{{Name: "Orbit", Description: "orbit"}}},
+	}}
+	b := Result{Assumptions: []Assumption{
+		{Type: "SubCategory", Word: "orbit", Values: []AssumptionValue{{Name: "Orbit", Description: "orbit"}}},
+		{Type: "Clash", Word: "mercury", Values: []AssumptionValue{planet, element, god}},
+		{Type: "Clash", Word: "venus", Values: []AssumptionValue{{Name: "Planet", Description: "a planet"}}},
+	}}
+
+	changes := AssumptionDiff(a, b)
+	assert.Equal(t, []AssumptionChange{
+		{Type: "Clash", Word: "mercury", Before: &element, After: &planet},
+		{Type: "Unit", Word: "m", Before: &AssumptionValue{Name: "Meters", Description: "meters"}},
+		{Type: "Clash", Word: "venus", After: &AssumptionValue{Name: "Planet", Description: "a planet"}},
+	}, changes)
+	assert.Empty(t, AssumptionDiff(a, a))
+}
+
+func TestAssumptionChange_String(t *testing.T) {
+	planet := AssumptionValue{Name: "Planet", Description: "a planet", Input: "*C.mercury-_*Planet-"}
+	element := AssumptionValue{Name: "Element", Description: "a chemical element", Input: "*C.mercury-_*Element-"}
+	assert.Equal(
+		t,
+		`now assuming "mercury" is a planet, not a chemical element`,
+		AssumptionChange{Word: "mercury", Before: &element, After: &planet}.String(),
+	)
+	assert.Equal(t, `now assuming "mercury" is a planet`, AssumptionChange{Word: "mercury", After: &planet}.String())
+	assert.Equal(t, `no longer assuming "mercury" is a planet`, AssumptionChange{Word: "mercury", Before: &planet}.String())
+	assert.Equal(t, `no change in assuming "mercury"`, AssumptionChange{Word: "mercury"}.String())
+}
+
+func TestResult_Interpretations(t *testing.T) {
+	assert.Nil(t, Result{Assumptions: []Assumption{
+		{Type: "Unit", Word: "m", Values: []AssumptionValue{{Name: "Meters"}}},
+	}}.Interpretations())
+
+	planet := AssumptionValue{Name: "Planet", Description: "a planet", Input: "*C.mercury-_*Planet-"}
+	element := AssumptionValue{Name: "Element", Description: "a chemical element", Input: "*C.mercury-_*Element-"}
+	roman := AssumptionValue{Name: "RomanGod", Word: "venus", Description: "a Roman god", Input: "*MC.venus-_*RomanGod-"}
+	venus := AssumptionValue{Name: "Planet", Word: "venus", Description: "a planet", Input: "*MC.venus-_*Planet-"}
+	interps := Result{Assumptions: []Assumption{
+		{Type: "Clash", Word: "mercury", Values: []AssumptionValue{planet, element}},
+		{Type: "Unit", Word: "m", Values: []AssumptionValue{{Name: "Meters"}}},
+		{Type: "MultiClash", Values: []AssumptionValue{roman, venus}},
+	}}.Interpretations()
+	assert.Equal(t, []Interpretation{
+		{Words: []string{"mercury", "venus"}, Values: []AssumptionValue{planet, roman}},
+		{Words: []string{"mercury", "venus"}, Values: []AssumptionValue{planet, venus}},
+		{Words: []string{"mercury", "venus"}, Values: []AssumptionValue{element, roman}},
+		{Words: []string{"mercury", "venus"}, Values: []AssumptionValue{element, venus}},
+	}, interps)
+	assert.Equal(t, []string{"*C.mercury-_*Element-", "*MC.venus-_*Planet-"}, interps[3].Assumptions())
+	assert.Equal(t, `"mercury" as a planet, "venus" as a Roman god`, interps[0].String())
+}
+
+func TestClient_ApplyAssumption(t *testing.T) {
+	planet := AssumptionValue{Name: "Planet", Description: "a planet", Input: "*C.mercury-_*Planet-"}
+	params := serve(t, resultXML)
+	c := Client{Extra: url.Values{"assumption": {"*U.m-_*Meters-"}}}
+	r, err := c.Query("mercury")
+	assert.NoError(t, err)
+	assert.Equal(t, "mercury", r.Input)
+
+	_, err = c.ApplyAssumption(r, planet)
+	assert.NoError(t, err)
+	assert.Equal(t, "mercury", params.Get("input"))
+	assert.Equal(t, []string{"*U.m-_*Meters-", planet.Input}, (*params)["assumption"])
+	assert.Equal(t, []string{"*U.m-_*Meters-"}, c.Extra["assumption"])
+
+	_, err = c.ApplyAssumption(Result{}, planet)
+	assert.EqualError(t, err, "api: result has no input to re-query")
+}
+
+func TestResult_AssumptionChoices(t *testing.T) {
+	params := serve(t, `<queryresult success="true">
+	                      <assumptions count="1">
+	                        <assumption type="Clash" word="mercury" count="3">
+	                          <value name="Element" desc="a chemical element" input="*C.mercury-_*Element-"/>
+	                          <value name="Planet" desc="a planet" input="*C.mercury-_*Planet-"/>
+	                          <value name="MythologicalFigure" desc="a mythological figure" input="*C.mercury-_*MythologicalFigure-"/>
+	                        </assumption>
+	                      </assumptions>
+	                    </queryresult>`)
+	r, err := Client{Units: Metric}.QueryWithOptions("mercury", WithUnits(Imperial))
+	assert.NoError(t, err)
+
+	var descriptions []string
+	var applies []func(context.Context) (Result, error)
+	for desc, apply := range r.AssumptionChoices() {
+		descriptions = append(descriptions, desc)
+		applies = append(applies, apply)
+	}
+	assert.Equal(t, []string{`"mercury" as a planet`, `"mercury" as a mythological figure`}, descriptions)
+
+	require.Len(t, applies, 2)
+	_, err = applies[1](context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "mercury", params.Get("input"))
+	assert.Equal(t, "nonmetric", params.Get("units"))
+	assert.Equal(t, []string{"*C.mercury-_*MythologicalFigure-"}, (*params)["assumption"])
+
+	for range (Result{Assumptions: r.Assumptions}).AssumptionChoices() {
+		t.Fatal("yielded a choice for a result without a query")
+	}
+}
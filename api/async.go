@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// AsyncPods returns the pods that are still being computed, which can be
+// fetched with Client.FetchPod. Results only have async pods if the query was
+// made with Client.Async set.
+func (r Result) AsyncPods() []Pod {
+	var pods []Pod
+	for _, pod := range r.Pods {
+		if pod.Async != "" {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// ErrPodFiltered occurs when a fetched pod is rejected by the client's
+// Filters.
+var ErrPodFiltered = errors.New("api: pod rejected by filter")
+
+// FetchPod fetches the contents of an async pod of the result from its Async
+// URL (see Client.Async). The URL is subject to the client's Redirects
+// policy. Pods that aren't async are returned as is. If the client's Filters
+// reject the fetched pod as part of the result, FetchPod returns the async
+// pod and an error wrapping ErrPodFiltered with the reason.
+func (c Client) FetchPod(ctx context.Context, r Result, pod Pod) (Pod, error) {
+	fetched, err := c.fetchPod(ctx, pod)
+	if err != nil {
+		return pod, err
+	}
+	if reason, ok := c.rejection(r, fetched); ok {
+		return pod, fmt.Errorf("%w: %s", ErrPodFiltered, reason)
+	}
+	return fetched, nil
+}
+
+// fetchPod implements FetchPod, without filtering.
+func (c Client) fetchPod(ctx context.Context, pod Pod) (Pod, error) {
+	if pod.Async == "" {
+		return pod, nil
+	}
+	data, err := c.fetch(ctx, pod.Async, c.OnResponse)
+	if err != nil {
+		return pod, err
+	}
+
+	var params url.Values
+	if u, err := url.Parse(pod.Async); err == nil {
+		params = u.Query()
+	}
+	var fetched Pod
+	if err := decode(data, Fingerprint(params), &fetched); err != nil {
+		return pod, err
+	}
+	if fetched.Error != nil {
+		return pod, fetched.Error
+	}
+	return fetched, nil
+}
+
+// FetchAsyncPods fetches all of the result's async pods, replacing them in
+// the result as they arrive, or removing them if the client's Filters reject
+// them (see Result.Filtered). Callers that want to show each pod as soon as
+// it's ready should call FetchPod for each of the result's AsyncPods instead.
+func (c Client) FetchAsyncPods(ctx context.Context, r *Result) error {
+	pods := r.Pods[:0:0]
+	for i, pod := range r.Pods {
+		fetched, err := c.fetchPod(ctx, pod)
+		if err != nil {
+			r.Pods = append(pods, r.Pods[i:]...)
+			return err
+		}
+		pods = append(pods, c.filterPods(r, []Pod{fetched})...)
+	}
+	r.Pods = pods
+	return nil
+}
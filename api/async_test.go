@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Async(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/query":
+			assert.Equal(t, "true", r.URL.Query().Get("async"))
+			w.Write([]byte(`<queryresult success="true">
+			                  <pod id="Input" position="100"><subpod><plaintext>integrate sin x</plaintext></subpod></pod>
+			                  <pod id="Plot" position="200" async="` + server.URL + `/pod?id=Plot&amp;s=1"/>
+			                  <pod id="Steps" position="300" async="` + server.URL + `/pod?id=Steps&amp;s=1"/>
+			                </queryresult>`))
+		case "/pod":
+			if r.URL.Query().Get("id") == "Steps" {
+				w.Write([]byte(`<pod id="Steps" error="true"><error><code>1</code><msg>failed</msg></error></pod>`))
+				return
+			}
+			w.Write([]byte(`<pod id="Plot" position="200"><subpod><plaintext>plot</plaintext></subpod></pod>`))
+		}
+	}))
+	defer server.Close()
+
+	c := Client{Endpoint: server.URL + "/query", Async: true}
+	result, err := c.Query("integrate sin x")
+	assert.NoError(t, err)
+	async := result.AsyncPods()
+	assert.Len(t, async, 2)
+
+	pod, err := c.FetchPod(context.Background(), result, async[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "plot", pod.Subpods[0].Plaintext)
+	assert.Empty(t, pod.Async)
+
+	pod, err = c.FetchPod(context.Background(), result, result.Pods[0])
+	assert.NoError(t, err)
+	assert.Equal(t, result.Pods[0], pod)
+
+	err = c.FetchAsyncPods(context.Background(), &result)
+	assert.Equal(t, &Error{Code: 1, Message: "failed"}, err)
+	assert.Equal(t, "plot", result.Pods[1].Subpods[0].Plaintext)
+	assert.Equal(t, []Pod{result.Pods[2]}, result.AsyncPods())
+}
+
+func TestClient_Async_filters(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/query":
+			w.Write([]byte(`<queryresult success="true">
+			                  <pod id="Input" position="100"><subpod><plaintext>ibuprofen</plaintext></subpod></pod>
+			                  <pod id="Dosage" position="200" async="` + server.URL + `/pod?id=Dosage"/>
+			                  <pod id="Missing" position="300" async="` + server.URL + `/pod?id=Missing"/>
+			                </queryresult>`))
+		case "/pod":
+			if r.URL.Query().Get("id") == "Missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`<pod id="Dosage" title="Dosage" scanner="Drug" position="200"><subpod><plaintext>200 mg</plaintext></subpod></pod>`))
+		}
+	}))
+	defer server.Close()
+
+	var statuses []int
+	c := Client{
+		Endpoint:   server.URL + "/query",
+		Async:      true,
+		Filters:    []Filter{MinorsFilter},
+		OnResponse: func(status int, body []byte) { statuses = append(statuses, status) },
+	}
+	result, err := c.Query("ibuprofen")
+	assert.NoError(t, err)
+
+	_, err = c.FetchPod(context.Background(), result, result.Pods[1])
+	assert.ErrorIs(t, err, ErrPodFiltered)
+	assert.EqualError(t, err, "api: pod rejected by filter: scanner Drug")
+
+	err = c.FetchAsyncPods(context.Background(), &result)
+	assert.Error(t, err)
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusNotFound}, statuses)
+	assert.Equal(t, []FilteredPod{{ID: "Dosage", Title: "Dosage", Reason: "scanner Drug"}}, result.Filtered)
+	if assert.Len(t, result.Pods, 2) {
+		assert.Equal(t, "Input", result.Pods[0].ID)
+		assert.Equal(t, "Missing", result.Pods[1].ID)
+	}
+}
+
+func TestClient_FetchPod_dataTypes(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<pod id="Effects" title="Effects" position="200"><subpod><plaintext>drowsiness</plaintext></subpod></pod>`))
+	}))
+	defer server.Close()
+
+	result := Result{DataTypes: "Drug", Pods: []Pod{{ID: "Effects", Async: server.URL + "/pod?id=Effects"}}}
+	c := Client{Filters: []Filter{MinorsFilter}}
+	pod, err := c.FetchPod(context.Background(), result, result.Pods[0])
+	assert.ErrorIs(t, err, ErrPodFiltered)
+	assert.EqualError(t, err, "api: pod rejected by filter: data type Drug")
+	assert.Equal(t, result.Pods[0], pod)
+
+	err = c.FetchAsyncPods(context.Background(), &result)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Pods)
+	assert.Equal(t, []FilteredPod{{ID: "Effects", Title: "Effects", Reason: "data type Drug"}}, result.Filtered)
+}
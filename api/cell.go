@@ -0,0 +1,67 @@
+package api
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Cell is a subpod's Mathematica notebook cell expression, returned when
+// the cell format is requested. Wolfram Alpha usually sends it compressed, in
+// the format of Mathematica's Compress function; Expression undoes that.
+type Cell struct {
+	// The tag name
+	XMLName struct{} `xml:"cell"`
+
+	// Whether the data is compressed
+	Compressed bool `xml:"compressed,attr"`
+
+	// The cell data, as sent
+	Data string `xml:",chardata"`
+}
+
+// Expression returns the cell expression, decompressing it if necessary, so
+// that it can be handed to Mathematica-side tooling as is (for example, with
+// ToExpression or by writing it into a notebook).
+func (c Cell) Expression() (string, error) {
+	data := strings.TrimSpace(c.Data)
+	if !c.Compressed {
+		return data, nil
+	}
+	expr, err := decompressCell(data)
+	if err != nil {
+		return "", fmt.Errorf("api: decoding cell: %w", err)
+	}
+	return expr, nil
+}
+
+// decompressCell decodes data in the format of Mathematica's Compress
+// function: a "1:" version prefix followed by zlib-compressed bytes encoded
+// in base64, possibly split across lines.
+func decompressCell(data string) (string, error) {
+	data = strings.TrimPrefix(data, "1:")
+	data = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, data)
+
+	compressed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	expr, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(expr), nil
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func compressCell(expr string) string {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	w.Write([]byte(expr))
+	w.Close()
+	encoded := base64.StdEncoding.EncodeToString(b.Bytes())
+	return "1:" + encoded[:10] + "\n" + encoded[10:]
+}
+
+func TestCellExpression(t *testing.T) {
+	const expr = `Cell[BoxData["3.048 m"], "Output"]`
+	data := `<subpod title=""><cell compressed="true"><![CDATA[` + compressCell(expr) + `]]></cell></subpod>`
+
+	var s Subpod
+	assert.NoError(t, xml.Unmarshal([]byte(data), &s))
+	if assert.NotNil(t, s.Cell) {
+		assert.True(t, s.Cell.Compressed)
+		got, err := s.Cell.Expression()
+		assert.NoError(t, err)
+		assert.Equal(t, expr, got)
+	}
+
+	got, err := Cell{Data: "\n" + expr + "\n"}.Expression()
+	assert.NoError(t, err)
+	assert.Equal(t, expr, got)
+}
+
+func TestCellExpressionInvalid(t *testing.T) {
+	_, err := Cell{Compressed: true, Data: "1:not base64!"}.Expression()
+	assert.ErrorContains(t, err, "api: decoding cell")
+
+	_, err = Cell{Compressed: true, Data: "1:" + base64.StdEncoding.EncodeToString([]byte("plain"))}.Expression()
+	assert.ErrorContains(t, err, "api: decoding cell")
+}
@@ -0,0 +1,451 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// liveAppID is the WOLFRAM_APP_ID the tests were run with, for the
+// integration tests.
+var liveAppID = os.Getenv("WOLFRAM_APP_ID")
+
+// TestMain clears the environment variables that fill in a client's unset
+// fields (see Client.applyEnv), so that the tests don't depend on the
+// developer's environment. Tests of the environment set them with t.Setenv.
+func TestMain(m *testing.M) {
+	for _, key := range []string{"WOLFRAM_APP_ID", "WOLFRAM_ENDPOINT", "WOLFRAM_TIMEOUT"} {
+		os.Unsetenv(key)
+	}
+	os.Exit(m.Run())
+}
+
+func TestClient_Clone(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.example.com:8080")
+	c := Client{
+		AppID:           "XXXX",
+		Proxy:           proxy,
+		Header:          http.Header{"X-Team": {"tutors"}},
+		Formats:         []Format{PlaintextFormat, ImageF},
+		Extra:           url.Values{"newknob": {"a"}},
+		Redirects:       RedirectPolicy{AllowedHosts: []string{"example.com"}},
+		ResponseHeaders: []string{},
+		Units:           Imperial,
+		LatLong:         &Coordinates{40.42, -3.7},
+	}
+	d := c.Clone()
+	assert.Equal(t, c, d)
+
+	d.Proxy.Host = "other.example.com"
+	d.Header.Set("X-Team", "admins")
+	d.Formats[0] = MathMLFormat
+	d.Extra["newknob"][0] = "b"
+	d.Redirects.AllowedHosts[0] = "example.org"
+	d.Units = Metric
+	d.LatLong.Latitude = 41.39
+	assert.Equal(t, "proxy.example.com:8080", c.Proxy.Host)
+	assert.Equal(t, "tutors", c.Header.Get("X-Team"))
+	assert.Equal(t, []Format{PlaintextFormat, ImageF}, c.Formats)
+	assert.Equal(t, url.Values{"newknob": {"a"}}, c.Extra)
+	assert.Equal(t, []string{"example.com"}, c.Redirects.AllowedHosts)
+	assert.Equal(t, Imperial, c.Units)
+	assert.Equal(t, &Coordinates{40.42, -3.7}, c.LatLong)
+
+	assert.Equal(t, Client{}, Client{}.Clone())
+}
+
+func TestClient_concurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+
+	c := Client{
+		AppIDs:    NewAppIDPool(RoundRobin, "A", "B"),
+		Endpoint:  server.URL,
+		Header:    http.Header{"X-Team": {"tutors"}},
+		Formats:   []Format{PlaintextFormat},
+		Extra:     url.Values{"newknob": {"a"}},
+		Filters:   []Filter{MinorsFilter},
+		Scrubbers: []Scrubber{EmailScrubber},
+	}
+	c.Use(func(req *http.Request) error {
+		req.Header.Add("X-Team", "shared")
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				_, err = c.Ask("10 feet in meters")
+			} else {
+				_, err = c.QueryWithOptions("pi", WithUnits(Metric), WithLocation("Madrid"))
+			}
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, http.Header{"X-Team": {"tutors"}}, c.Header)
+}
+
+func TestClient_SetIPAddress(t *testing.T) {
+	var c Client
+	c.SetIPAddress(netip.MustParseAddr("2001:db8::1"))
+	assert.Equal(t, "2001:db8::1", c.IPAddress)
+}
+
+func TestClient_SetLatLong(t *testing.T) {
+	params := serve(t, resultXML)
+	var c Client
+	assert.NoError(t, c.SetLatLong("40.42, -3.71"))
+	assert.Equal(t, &Coordinates{40.42, -3.71}, c.LatLong)
+	_, err := c.Query("weather")
+	assert.NoError(t, err)
+	assert.Equal(t, "40.42,-3.71", params.Get("latlong"))
+
+	assert.NoError(t, c.SetLatLong(`40°30'N, 3°42'W`))
+	assert.Equal(t, &Coordinates{40.5, -3.7}, c.LatLong)
+
+	assert.Error(t, c.SetLatLong("40.42"))
+	assert.Error(t, c.SetLatLong("91,0"))
+	assert.Equal(t, &Coordinates{40.5, -3.7}, c.LatLong)
+}
+
+func TestClient_CheckConfig(t *testing.T) {
+	assert.NoError(t, Client{}.CheckConfig())
+	assert.NoError(t, Client{IPAddress: "192.0.2.1"}.CheckConfig())
+	assert.NoError(t, Client{IPAddress: "2001:db8::1"}.CheckConfig())
+	assert.NoError(t, Client{Location: "Madrid"}.CheckConfig())
+
+	err := Client{IPAddress: "192.0.2"}.CheckConfig()
+	assert.Equal(t, &ConfigError{Field: "IPAddress", Message: "invalid IP address 192.0.2"}, err)
+	assert.EqualError(t, err, "api: invalid IPAddress: invalid IP address 192.0.2")
+
+	err = Client{IPAddress: "192.0.2.1", Location: "Madrid"}.CheckConfig()
+	assert.Equal(t, &ConfigError{Field: "Location", Message: "conflicts with IPAddress"}, err)
+
+	err = Client{LatLong: &Coordinates{40.42, -3.71}, Location: "Madrid"}.CheckConfig()
+	assert.Equal(t, &ConfigError{Field: "Location", Message: "conflicts with LatLong"}, err)
+
+	err = Client{LatLong: &Coordinates{-3.71, 240.42}}.CheckConfig()
+	assert.Equal(t, &ConfigError{Field: "LatLong", Message: "coordinates out of range 3.71°S, 240.42°E"}, err)
+
+	assert.NoError(t, Client{Currency: "EUR", CountryCode: "DE"}.CheckConfig())
+	err = Client{Currency: "eur"}.CheckConfig()
+	assert.Equal(t, &ConfigError{Field: "Currency", Message: "invalid ISO 4217 code eur"}, err)
+	err = Client{CountryCode: "DEU"}.CheckConfig()
+	assert.Equal(t, &ConfigError{Field: "CountryCode", Message: "invalid ISO 3166 code DEU"}, err)
+}
+
+// serve points queries at a test server that responds with the given XML, and
+// returns the query parameters of the last request it received. It clears the
+// environment variables that would fill in the test client's unset fields.
+func serve(t *testing.T, body string) *url.Values {
+	t.Setenv("WOLFRAM_APP_ID", "")
+	t.Setenv("WOLFRAM_ENDPOINT", "")
+	t.Setenv("WOLFRAM_TIMEOUT", "")
+	var params url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params = r.URL.Query()
+		w.Write([]byte(body))
+	}))
+	orig := queryURL
+	queryURL = server.URL
+	t.Cleanup(func() {
+		queryURL = orig
+		server.Close()
+	})
+	return &params
+}
+
+const resultXML = `
+  <queryresult success="true" error="false" numpods="2" version="2.6">
+    <pod title="Input interpretation" id="Input" position="100">
+      <subpod title=""><plaintext>convert 10 feet to meters</plaintext></subpod>
+    </pod>
+    <pod title="Result" id="Result" position="200" primary="true">
+      <subpod title=""><plaintext>3.048 meters</plaintext></subpod>
+    </pod>
+  </queryresult>`
+
+func TestFormat_String(t *testing.T) {
+	assert.Equal(t, "plaintext", PlaintextFormat.String())
+	assert.Equal(t, "image", ImageF.String())
+	assert.Equal(t, "wav", WavFormat.String())
+	assert.Equal(t, "Format(42)", Format(42).String())
+}
+
+func TestClient_Query(t *testing.T) {
+	params := serve(t, resultXML)
+	c := Client{
+		AppID:              "APPID",
+		Formats:            []Format{PlaintextFormat, ImageF},
+		ImageWidth:         300,
+		ImageMagnification: 2,
+		Location:           "Madrid",
+		Reinterpret:        ToggleOn,
+		IgnoreCase:         true,
+		Units:              Metric,
+		ScanTimeout:        time.Second,
+		PodTimeout:         2500 * time.Millisecond,
+	}
+	result, err := c.Query("10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"appid":       {"APPID"},
+		"input":       {"10 feet in meters"},
+		"format":      {"plaintext,image"},
+		"width":       {"300"},
+		"mag":         {"2"},
+		"scantimeout": {"1"},
+		"podtimeout":  {"2.5"},
+		"location":    {"Madrid"},
+		"reinterpret": {"true"},
+		"ignorecase":  {"true"},
+		"units":       {"metric"},
+	}, *params)
+	assert.Len(t, result.Pods, 2)
+	assert.Equal(t, "3.048 meters", result.Pods[1].Subpods[0].Plaintext)
+
+	_, err = Client{AppID: "APPID", Units: Location}.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{"appid": {"APPID"}, "input": {"pi"}}, *params)
+}
+
+func TestClient_Query_timeoutParams(t *testing.T) {
+	params := serve(t, resultXML)
+	c := Client{FormatTimeout: 8 * time.Second, ParseTimeout: 5 * time.Second}
+	_, err := c.QueryWithOptions("pi", WithScanTimeout(500*time.Millisecond), WithPodTimeout(4*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5", params.Get("scantimeout"))
+	assert.Equal(t, "4", params.Get("podtimeout"))
+	assert.Equal(t, "8", params.Get("formattimeout"))
+	assert.Equal(t, "5", params.Get("parsetimeout"))
+
+	_, err = c.QueryWithOptions("pi", WithFormatTimeout(time.Second), WithParseTimeout(2*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", params.Get("formattimeout"))
+	assert.Equal(t, "2", params.Get("parsetimeout"))
+
+	_, err = Client{PodTimeout: -time.Second}.Query("pi")
+	assert.Equal(t, &ConfigError{Field: "PodTimeout", Message: "negative timeout -1s"}, err)
+}
+
+func TestClient_Query_endpoint(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+
+	_, err := Client{Endpoint: server.URL + "/wolfram/query"}.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, "/wolfram/query", path)
+}
+
+func TestClient_Query_extra(t *testing.T) {
+	params := serve(t, resultXML)
+	c := Client{
+		AppID: "APPID",
+		Units: Metric,
+		Extra: url.Values{"units": {"nonmetric"}, "newknob": {"a", "b"}},
+	}
+	_, err := c.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"appid":   {"APPID"},
+		"input":   {"pi"},
+		"units":   {"nonmetric"},
+		"newknob": {"a", "b"},
+	}, *params)
+	assert.Equal(t, url.Values{"units": {"nonmetric"}, "newknob": {"a", "b"}}, c.Extra)
+}
+
+func TestClient_Query_errors(t *testing.T) {
+	serve(t, `<queryresult success="false" error="true">
+	            <error><code>1</code><msg>Invalid appid</msg></error>
+	          </queryresult>`)
+	result, err := Client{AppID: "BAD"}.Query("pi")
+	assert.Equal(t, &Error{Code: 1, Message: "Invalid appid"}, err)
+	assert.True(t, result.Errored)
+
+	_, err = Client{}.Query(" ")
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+
+	_, err = Client{IPAddress: "192.0.2.1", Location: "Madrid"}.Query("pi")
+	assert.IsType(t, &ConfigError{}, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Client{}.QueryContext(ctx, "pi")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestClient_OnResponse(t *testing.T) {
+	serve(t, resultXML)
+	var statuses []int
+	var bodies []string
+	c := Client{OnResponse: func(status int, body []byte) {
+		statuses = append(statuses, status)
+		bodies = append(bodies, string(body))
+	}}
+	_, err := c.Query("10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{http.StatusOK}, statuses)
+	assert.Equal(t, []string{resultXML}, bodies)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("upstream error"))
+	}))
+	defer server.Close()
+	c.Endpoint = server.URL
+	_, err = c.Query("pi")
+	assert.EqualError(t, err, "api: unexpected response status 502 Bad Gateway")
+	assert.Equal(t, []int{http.StatusOK, http.StatusBadGateway}, statuses)
+	assert.Equal(t, "upstream error", bodies[1])
+}
+
+func TestClient_ResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "Apache")
+		w.Header().Set("X-Request-Id", "abc123")
+		w.Header().Set("X-Debug-Host", "node7")
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+
+	result, err := Client{Endpoint: server.URL}.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, "Apache", result.ResponseMeta.Get("Server"))
+	assert.Equal(t, "abc123", result.ResponseMeta.Get("X-Request-Id"))
+	assert.NotEmpty(t, result.ResponseMeta.Get("Date"))
+	assert.Empty(t, result.ResponseMeta.Get("X-Debug-Host"))
+
+	result, err = Client{Endpoint: server.URL, ResponseHeaders: []string{"x-debug-host"}}.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, http.Header{"X-Debug-Host": {"node7"}}, result.ResponseMeta)
+
+	result, err = Client{Endpoint: server.URL, ResponseHeaders: []string{}}.Query("pi")
+	assert.NoError(t, err)
+	assert.Nil(t, result.ResponseMeta)
+}
+
+func TestClient_BuildQueryURL(t *testing.T) {
+	c := Client{
+		AppID:      "XXXX",
+		Formats:    []Format{PlaintextFormat, ImageF},
+		ImageWidth: 300,
+		Location:   "Madrid",
+		Units:      Metric,
+	}
+	u, err := c.BuildQueryURL("10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		queryURL+"?appid=XXXX&format=plaintext%2Cimage&input=10+feet+in+meters&location=Madrid&units=metric&width=300",
+		u.String(),
+	)
+
+	c = Client{Endpoint: "https://example.com/wa", AppIDs: NewAppIDPool(RoundRobin, "A", "B")}
+	u, err = c.BuildQueryURL("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/wa?appid=A&input=pi", u.String())
+
+	_, err = Client{}.BuildQueryURL("")
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	_, err = Client{IPAddress: "nope"}.BuildQueryURL("pi")
+	assert.IsType(t, &ConfigError{}, err)
+}
+
+func TestClient_Ask(t *testing.T) {
+	serve(t, resultXML)
+	answer, err := Client{}.Ask("10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.048 meters", answer)
+
+	serve(t, `<queryresult success="false" error="false">
+	            <didyoumeans><didyoumean>mustang moon</didyoumean></didyoumeans>
+	          </queryresult>`)
+	_, err = Client{}.AskContext(context.Background(), "mustagn moon")
+	var noContent *NoContentError
+	assert.True(t, errors.As(err, &noContent))
+}
+
+// roundTripFunc adapts a function into an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_HTTPClient(t *testing.T) {
+	var requests []string
+	c := Client{HTTPClient: &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.URL.Host+req.URL.Path)
+			body := resultXML
+			if req.URL.Path == "/image" {
+				body = "GIF"
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}}
+	_, err := c.Query("10 feet in meters")
+	assert.NoError(t, err)
+	_, err = c.fetch(context.Background(), "http://www.wolframalpha.com/image", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api.wolframalpha.com/v2/query", "www.wolframalpha.com/image"}, requests)
+}
+
+func TestClient_Query_timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+	orig := queryURL
+	queryURL = server.URL
+	defer func() { queryURL = orig }()
+
+	c := Client{Timeout: 10 * time.Millisecond}
+	_, err := c.Query("pi")
+	var timeoutErr *TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.EqualError(t, err, "api: query timed out after 10ms")
+
+	_, err = c.QueryContext(WithQueryTimeout(context.Background(), 20*time.Millisecond), "pi")
+	assert.EqualError(t, err, "api: query timed out after 20ms")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = Client{}.QueryContext(ctx, "pi")
+	assert.EqualError(t, err, "api: query timed out")
+
+	_, err = c.QueryContext(WithQueryTimeout(context.Background(), 0), "pi")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// contextKey is the type of keys for values this package stores in contexts.
+type contextKey int
+
+const (
+	optionsKey contextKey = iota
+)
+
+// WithContextOptions returns a copy of ctx that applies the options to every
+// query sent with it, overriding both the client's configuration and the
+// query's own options. Options attached by nested calls apply after those of
+// the outer ones, so the innermost wins.
+func WithContextOptions(ctx context.Context, opts ...QueryOption) context.Context {
+	prev, _ := ctx.Value(optionsKey).([]QueryOption)
+	all := make([]QueryOption, 0, len(prev)+len(opts))
+	all = append(append(all, prev...), opts...)
+	return context.WithValue(ctx, optionsKey, all)
+}
+
+// WithQueryTimeout returns a copy of ctx that makes queries sent with it time
+// out after d, overriding the client's Timeout. A zero d disables the timeout.
+//
+// Unlike context.WithTimeout, the timeout starts when the query does, not
+// when WithQueryTimeout is called, and it can be longer than the client's.
+func WithQueryTimeout(ctx context.Context, d time.Duration) context.Context {
+	return WithContextOptions(ctx, WithTimeout(d))
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Coordinates define a point on the Earth's surface, in decimal degrees.
+// Latitudes south of the equator and longitudes west of the prime meridian
+// are negative.
+type Coordinates struct {
+	// The latitude, from -90 to 90
+	Latitude float64
+
+	// The longitude, from -180 to 180
+	Longitude float64
+}
+
+// A MapProvider returns the URL of a static map image showing the given
+// coordinates at the given zoom level (0 shows the whole world; each
+// additional level doubles the scale).
+type MapProvider func(c Coordinates, zoom int) string
+
+// maxMercatorLatitude is the latitude at which the Web Mercator projection
+// used by map tiles is cut off, making the world map square.
+const maxMercatorLatitude = 85.0511
+
+// OpenStreetMap is a MapProvider returning the URL of the standard
+// OpenStreetMap tile containing the coordinates. Latitudes beyond the map's
+// edge (about 85.05° north or south) get the tiles along the edge, and a
+// longitude of 180° wraps around to -180°. See
+// https://wiki.openstreetmap.org/wiki/Slippy_map_tilenames for details.
+func OpenStreetMap(c Coordinates, zoom int) string {
+	n := math.Exp2(float64(zoom))
+	lat := math.Max(-maxMercatorLatitude, math.Min(maxMercatorLatitude, c.Latitude)) * math.Pi / 180
+	long := math.Mod(math.Mod(c.Longitude+180, 360)+360, 360)
+	x := tile(long/360*n, n)
+	y := tile((1-math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi)/2*n, n)
+	return fmt.Sprintf("https://tile.openstreetmap.org/%d/%d/%d.png", zoom, x, y)
+}
+
+// tile returns the index of the tile containing the position f, in tiles,
+// on an axis n tiles long.
+func tile(f, n float64) int {
+	return int(math.Max(0, math.Min(n-1, math.Floor(f))))
+}
+
+// ParseCoordinates parses coordinates from Wolfram Alpha plaintext. It
+// accepts degrees-minutes-seconds with hemispheres ("40°42'46"N, 74°0'22"W"),
+// decimal degrees with hemispheres ("48.86°N 2.35°E"), and signed decimal
+// pairs ("40.71, -74.01").
+func ParseCoordinates(s string) (Coordinates, error) {
+	var c Coordinates
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '|'
+	})
+	if len(fields) != 2 {
+		return c, errors.New("api: invalid coordinates: " + s)
+	}
+
+	lat, latHem, err := parseDegrees(fields[0])
+	if err != nil {
+		return c, errors.New("api: invalid latitude: " + fields[0])
+	}
+	long, longHem, err := parseDegrees(fields[1])
+	if err != nil {
+		return c, errors.New("api: invalid longitude: " + fields[1])
+	}
+
+	switch {
+	case latHem == 0 && longHem == 0:
+	case (latHem == 'N' || latHem == 'S') && (longHem == 'E' || longHem == 'W'):
+		if latHem == 'S' {
+			lat = -lat
+		}
+		if longHem == 'W' {
+			long = -long
+		}
+	default:
+		return c, errors.New("api: invalid coordinates: " + s)
+	}
+
+	if lat < -90 || lat > 90 || long < -180 || long > 180 {
+		return c, errors.New("api: coordinates out of range: " + s)
+	}
+	c.Latitude, c.Longitude = lat, long
+	return c, nil
+}
+
+// Coordinates returns the coordinates given by the result's first coordinate
+// or map pod, or false if the result has no such pod.
+func (r Result) Coordinates() (Coordinates, bool) {
+	for _, pod := range r.Pods {
+		title := strings.ToLower(pod.Title)
+		if !strings.Contains(title, "coordinates") && !strings.Contains(title, "map") &&
+			!strings.Contains(pod.ID, "Coordinates") && !strings.Contains(pod.ID, "Map") {
+			continue
+		}
+		for _, subpod := range pod.Subpods {
+			if c, err := ParseCoordinates(subpod.Plaintext); err == nil {
+				return c, true
+			}
+		}
+	}
+	return Coordinates{}, false
+}
+
+// MapURL returns the URL of a static map image showing the coordinates, as
+// built by the provider. If provider is nil, OpenStreetMap is used.
+func (c Coordinates) MapURL(zoom int, provider MapProvider) string {
+	if provider == nil {
+		provider = OpenStreetMap
+	}
+	return provider(c, zoom)
+}
+
+// String returns the coordinates in decimal degrees with hemispheres, like
+// "40.7128°N, 74.006°W".
+func (c Coordinates) String() string {
+	lat, long := 'N', 'E'
+	if c.Latitude < 0 {
+		lat = 'S'
+	}
+	if c.Longitude < 0 {
+		long = 'W'
+	}
+	return fmt.Sprintf("%s°%c, %s°%c",
+		strconv.FormatFloat(math.Abs(c.Latitude), 'f', -1, 64), lat,
+		strconv.FormatFloat(math.Abs(c.Longitude), 'f', -1, 64), long)
+}
+
+// parseDegrees parses a single angle like "40°42'46"N", "48.86°N", or
+// "-74.01", returning its value in decimal degrees and its hemisphere letter
+// (or zero if none was given).
+func parseDegrees(s string) (float64, byte, error) {
+	var hem byte
+	if n := len(s); n > 0 {
+		switch s[n-1] {
+		case 'N', 'S', 'E', 'W':
+			hem, s = s[n-1], s[:n-1]
+		}
+	}
+
+	if !strings.ContainsAny(s, "°'′\"″") {
+		deg, err := strconv.ParseFloat(s, 64)
+		if err == nil && hem != 0 && deg < 0 {
+			err = errors.New("api: negative angle with hemisphere")
+		}
+		return deg, hem, err
+	}
+
+	var deg float64
+	for _, unit := range []string{"°", "'", "′", "\"", "″"} {
+		i := strings.Index(s, unit)
+		if i < 0 {
+			continue
+		}
+		v, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil || v < 0 {
+			return 0, 0, errors.New("api: invalid angle")
+		}
+		switch unit {
+		case "°":
+			deg += v
+		case "'", "′":
+			deg += v / 60
+		default:
+			deg += v / 3600
+		}
+		s = s[i+len(unit):]
+	}
+	if s != "" {
+		return 0, 0, errors.New("api: invalid angle")
+	}
+	return deg, hem, nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseCoordinates(t *testing.T) {
+	tests := map[string]Coordinates{
+		`40°42'46"N, 74°0'22"W`: {40.71277777777778, -74.00611111111111},
+		`33°51′S 151°12′E`:      {-33.85, 151.2},
+		"48.86°N, 2.35°E":       {48.86, 2.35},
+		"40.71, -74.01":         {40.71, -74.01},
+	}
+	for s, expected := range tests {
+		c, err := ParseCoordinates(s)
+		assert.NoError(t, err, s)
+		assert.InDelta(t, expected.Latitude, c.Latitude, 1e-9, s)
+		assert.InDelta(t, expected.Longitude, c.Longitude, 1e-9, s)
+	}
+
+	for _, s := range []string{"", "40.71", "N, W", "40°N, 74°N", "-40°N, 74°W", "91, 0", "40°42x, 74°"} {
+		_, err := ParseCoordinates(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestCoordinates_MapURL(t *testing.T) {
+	c := Coordinates{Latitude: 51.5074, Longitude: -0.1278}
+	assert.Equal(t, "https://tile.openstreetmap.org/10/511/340.png", c.MapURL(10, nil))
+	assert.Equal(t, "51.5074,-0.1278@3", c.MapURL(3, func(c Coordinates, zoom int) string {
+		return fmt.Sprintf("%v,%v@%d", c.Latitude, c.Longitude, zoom)
+	}))
+}
+
+func TestOpenStreetMap(t *testing.T) {
+	tests := map[Coordinates]string{
+		{Latitude: 90, Longitude: 0}:        "https://tile.openstreetmap.org/2/2/0.png",
+		{Latitude: 85.0511, Longitude: 0}:   "https://tile.openstreetmap.org/2/2/0.png",
+		{Latitude: -90, Longitude: 0}:       "https://tile.openstreetmap.org/2/2/3.png",
+		{Latitude: 0, Longitude: 180}:       "https://tile.openstreetmap.org/2/0/2.png",
+		{Latitude: 0, Longitude: -180}:      "https://tile.openstreetmap.org/2/0/2.png",
+		{Latitude: 0, Longitude: 179.9999}:  "https://tile.openstreetmap.org/2/3/2.png",
+		{Latitude: -90, Longitude: 180}:     "https://tile.openstreetmap.org/2/0/3.png",
+		{Latitude: 51.5074, Longitude: 0.1}: "https://tile.openstreetmap.org/2/2/1.png",
+	}
+	for c, expected := range tests {
+		assert.Equal(t, expected, OpenStreetMap(c, 2), c.String())
+	}
+}
+
+func TestCoordinates_String(t *testing.T) {
+	assert.Equal(t, "40.7128°N, 74.006°W", Coordinates{40.7128, -74.006}.String())
+}
+
+func TestResult_Coordinates(t *testing.T) {
+	result := Result{Pods: []Pod{
+		{ID: "Input", Subpods: []Subpod{{Plaintext: "40.71, -74.01"}}},
+		{ID: "Coordinates", Title: "Coordinates", Subpods: []Subpod{{Plaintext: "48.86°N, 2.35°E"}}},
+	}}
+	c, ok := result.Coordinates()
+	assert.True(t, ok)
+	assert.Equal(t, Coordinates{48.86, 2.35}, c)
+
+	_, ok = Result{}.Coordinates()
+	assert.False(t, ok)
+}
@@ -0,0 +1,31 @@
+package api
+
+// A CurrencyCode is an ISO 4217 currency code, like "EUR" or "JPY".
+type CurrencyCode string
+
+// A CountryCode is an ISO 3166-1 alpha-2 country code, like "DE" or "JP".
+type CountryCode string
+
+// Valid reports whether the code is well-formed: three uppercase letters.
+func (c CurrencyCode) Valid() bool {
+	return isUpperAlpha(string(c), 3)
+}
+
+// Valid reports whether the code is well-formed: two uppercase letters.
+func (c CountryCode) Valid() bool {
+	return isUpperAlpha(string(c), 2)
+}
+
+// isUpperAlpha reports whether s consists of exactly n ASCII uppercase
+// letters.
+func isUpperAlpha(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
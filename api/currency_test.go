@@ -0,0 +1,19 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCurrencyCode_Valid(t *testing.T) {
+	assert.True(t, CurrencyCode("JPY").Valid())
+	assert.False(t, CurrencyCode("jpy").Valid())
+	assert.False(t, CurrencyCode("YEN!").Valid())
+	assert.False(t, CurrencyCode("").Valid())
+}
+
+func TestCountryCode_Valid(t *testing.T) {
+	assert.True(t, CountryCode("JP").Valid())
+	assert.False(t, CountryCode("JPN").Valid())
+	assert.False(t, CountryCode("J1").Valid())
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An Era defines the era in which a HistoricalDate's year is counted.
+type Era int
+
+const (
+	// Anno Domini (or Common Era)
+	AD Era = iota
+
+	// Before Christ (or Before Common Era)
+	BC
+)
+
+// A HistoricalDate represents a date as written in Wolfram Alpha plaintext.
+// Answers to history and astronomy queries often use dates that time.Time
+// can't represent, or can't represent honestly: years before the common era,
+// approximate dates, and spans like "13.8 billion years ago".
+//
+// For example, the plaintext "March 15, 44 BC" is parsed as the HistoricalDate
+// {Year: 44, Month: time.March, Day: 15, Era: BC}, and "about 4.5 billion years
+// ago" as {YearsAgo: 4.5e9, Approximate: true}.
+type HistoricalDate struct {
+	// The year, counted from the start of the era (zero for relative dates)
+	Year int64
+
+	// The month, or zero if the date is only precise to the year
+	Month time.Month
+
+	// The day of the month, or zero if the date is only precise to the month
+	Day int
+
+	// The era in which the year is counted
+	Era Era
+
+	// The number of years before the present, for relative dates like
+	// "65 million years ago" (zero for calendar dates)
+	YearsAgo float64
+
+	// Whether the date is qualified as approximate ("about", "circa", etc.)
+	Approximate bool
+}
+
+// A DateSpan represents a range of dates, like "1939 to 1945".
+type DateSpan struct {
+	// The first date in the span
+	Start HistoricalDate
+
+	// The last date in the span
+	End HistoricalDate
+}
+
+var (
+	dateApproximations = []string{"about ", "approximately ", "approx. ", "around ", "circa ", "ca. ", "c. ", "~"}
+	dateMagnitudes     = map[string]float64{
+		"thousand": 1e3,
+		"million":  1e6,
+		"billion":  1e9,
+		"trillion": 1e12,
+	}
+	dateSpanSeparators = []string{" to ", " - ", " – ", " — ", "–", "—"}
+)
+
+// ParseHistoricalDate parses a date from Wolfram Alpha plaintext. It accepts
+// calendar dates ("Thursday, July 4, 1776", "July 1776", "4 July 1776",
+// "1776"), dates with an era ("44 BC", "AD 79", "500 BCE"), and relative dates
+// ("13.8 billion years ago"), each optionally qualified as approximate.
+func ParseHistoricalDate(s string) (HistoricalDate, error) {
+	var date HistoricalDate
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return date, errors.New("api: empty date")
+	}
+
+	lower := strings.ToLower(s)
+	for _, prefix := range dateApproximations {
+		if strings.HasPrefix(lower, prefix) {
+			date.Approximate = true
+			s, lower = s[len(prefix):], lower[len(prefix):]
+			break
+		}
+	}
+
+	for _, suffix := range []string{" years ago", " year ago"} {
+		if strings.HasSuffix(lower, suffix) {
+			ago, err := parseDateMagnitude(s[:len(s)-len(suffix)])
+			if err != nil {
+				return date, errors.New("api: invalid relative date: " + s)
+			}
+			date.YearsAgo = ago
+			return date, nil
+		}
+	}
+
+	fields := strings.Fields(s)
+	switch last := strings.ToUpper(fields[len(fields)-1]); {
+	case last == "BC" || last == "BCE" || last == "B.C.":
+		date.Era = BC
+		fields = fields[:len(fields)-1]
+	case last == "AD" || last == "CE" || last == "A.D.":
+		fields = fields[:len(fields)-1]
+	case len(fields) > 1 && (strings.ToUpper(fields[0]) == "AD" || strings.ToUpper(fields[0]) == "A.D."):
+		fields = fields[1:]
+	}
+
+	// Drop a leading weekday, as in "Thursday, July 4, 1776".
+	if len(fields) > 0 && strings.HasSuffix(fields[0], ",") {
+		if _, ok := parseWeekday(strings.TrimSuffix(fields[0], ",")); ok {
+			fields = fields[1:]
+		}
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSuffix(fields[i], ",")
+	}
+
+	var ok bool
+	switch len(fields) {
+	case 1:
+		date.Year, ok = parseDateYear(fields[0])
+	case 2:
+		if date.Month, ok = parseMonth(fields[0]); ok {
+			date.Year, ok = parseDateYear(fields[1])
+		}
+	case 3:
+		if date.Month, ok = parseMonth(fields[0]); ok {
+			date.Day, ok = parseDateDay(fields[1])
+		} else if date.Month, ok = parseMonth(fields[1]); ok {
+			date.Day, ok = parseDateDay(fields[0])
+		}
+		if ok {
+			date.Year, ok = parseDateYear(fields[2])
+		}
+	}
+	if !ok {
+		return HistoricalDate{}, errors.New("api: invalid date: " + s)
+	}
+	return date, nil
+}
+
+// ParseDateSpan parses a range of dates from Wolfram Alpha plaintext, like
+// "July 4, 1776 to September 3, 1783" or "1939–1945". A BC era given only on
+// the end of the span ("500–400 BC") also applies to the start.
+func ParseDateSpan(s string) (DateSpan, error) {
+	for _, sep := range dateSpanSeparators {
+		i := strings.Index(s, sep)
+		if i < 0 {
+			continue
+		}
+		start, err := ParseHistoricalDate(s[:i])
+		if err != nil {
+			return DateSpan{}, err
+		}
+		end, err := ParseHistoricalDate(s[i+len(sep):])
+		if err != nil {
+			return DateSpan{}, err
+		}
+		if end.Era == BC && start.Year > end.Year {
+			start.Era = BC
+		}
+		return DateSpan{Start: start, End: end}, nil
+	}
+	return DateSpan{}, errors.New("api: invalid date span: " + s)
+}
+
+// Relative reports whether the date is given relative to the present, like
+// "10,000 years ago", rather than as a calendar date.
+func (d HistoricalDate) Relative() bool {
+	return d.YearsAgo != 0
+}
+
+// Time returns the date as a UTC time.Time at midnight, with missing months
+// and days defaulting to January and the first. BC years are converted to
+// astronomical year numbering (1 BC is year 0). The boolean is false for
+// relative dates and for years too large for time.Time to represent.
+func (d HistoricalDate) Time() (time.Time, bool) {
+	if d.Relative() || d.Year > 1e9 {
+		return time.Time{}, false
+	}
+	year := d.Year
+	if d.Era == BC {
+		year = 1 - year
+	}
+	month, day := d.Month, d.Day
+	if month == 0 {
+		month = time.January
+	}
+	if day == 0 {
+		day = 1
+	}
+	return time.Date(int(year), month, day, 0, 0, 0, 0, time.UTC), true
+}
+
+// String returns the date in the same style Wolfram Alpha uses in plaintext.
+func (d HistoricalDate) String() string {
+	var s string
+	if d.Approximate {
+		s = "about "
+	}
+	if d.Relative() {
+		return s + strconv.FormatFloat(d.YearsAgo, 'f', -1, 64) + " years ago"
+	}
+	if d.Month != 0 {
+		s += d.Month.String() + " "
+		if d.Day != 0 {
+			s += strconv.Itoa(d.Day) + ", "
+		}
+	}
+	s += strconv.FormatInt(d.Year, 10)
+	if d.Era == BC {
+		s += " BC"
+	}
+	return s
+}
+
+// parseDateMagnitude parses a number of years like "13.8 billion" or "10,000".
+func parseDateMagnitude(s string) (float64, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, errors.New("api: invalid magnitude: " + s)
+	}
+	n, err := strconv.ParseFloat(strings.Replace(fields[0], ",", "", -1), 64)
+	if err != nil {
+		return 0, err
+	}
+	if len(fields) == 2 {
+		m, ok := dateMagnitudes[strings.ToLower(fields[1])]
+		if !ok {
+			return 0, errors.New("api: invalid magnitude: " + s)
+		}
+		n *= m
+	}
+	return n, nil
+}
+
+func parseDateYear(s string) (int64, bool) {
+	y, err := strconv.ParseInt(strings.Replace(s, ",", "", -1), 10, 64)
+	return y, err == nil && y > 0
+}
+
+func parseDateDay(s string) (int, bool) {
+	d, err := strconv.Atoi(s)
+	return d, err == nil && d >= 1 && d <= 31
+}
+
+func parseMonth(s string) (time.Month, bool) {
+	s = strings.ToLower(strings.TrimSuffix(s, "."))
+	if len(s) < 3 {
+		return 0, false
+	}
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), s) {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) {
+			return d, true
+		}
+	}
+	return 0, false
+}
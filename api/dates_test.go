@@ -0,0 +1,74 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseHistoricalDate(t *testing.T) {
+	tests := map[string]HistoricalDate{
+		"Thursday, July 4, 1776": {Year: 1776, Month: time.July, Day: 4},
+		"July 1776":              {Year: 1776, Month: time.July},
+		"4 July 1776":            {Year: 1776, Month: time.July, Day: 4},
+		"1776":                   {Year: 1776},
+		"March 15, 44 BC":        {Year: 44, Month: time.March, Day: 15, Era: BC},
+		"500 BCE":                {Year: 500, Era: BC},
+		"AD 79":                  {Year: 79},
+		"10,000 BC":              {Year: 10000, Era: BC},
+		"circa 3000 BC":          {Year: 3000, Era: BC, Approximate: true},
+		"13.8 billion years ago": {YearsAgo: 13.8e9},
+		"about 65 million years ago": {
+			YearsAgo:    65e6,
+			Approximate: true,
+		},
+	}
+	for s, expected := range tests {
+		date, err := ParseHistoricalDate(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, date, s)
+	}
+
+	for _, s := range []string{"", "yesterday", "Smarch 4, 1776", "0 BC", "many years ago"} {
+		_, err := ParseHistoricalDate(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseDateSpan(t *testing.T) {
+	span, err := ParseDateSpan("July 4, 1776 to September 3, 1783")
+	assert.NoError(t, err)
+	assert.Equal(t, DateSpan{
+		Start: HistoricalDate{Year: 1776, Month: time.July, Day: 4},
+		End:   HistoricalDate{Year: 1783, Month: time.September, Day: 3},
+	}, span)
+
+	span, err = ParseDateSpan("500–400 BC")
+	assert.NoError(t, err)
+	assert.Equal(t, DateSpan{
+		Start: HistoricalDate{Year: 500, Era: BC},
+		End:   HistoricalDate{Year: 400, Era: BC},
+	}, span)
+
+	_, err = ParseDateSpan("1776")
+	assert.Error(t, err)
+}
+
+func TestHistoricalDate_Time(t *testing.T) {
+	tm, ok := HistoricalDate{Year: 1776, Month: time.July, Day: 4}.Time()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(1776, time.July, 4, 0, 0, 0, 0, time.UTC), tm)
+
+	tm, ok = HistoricalDate{Year: 1, Era: BC}.Time()
+	assert.True(t, ok)
+	assert.Equal(t, 0, tm.Year())
+
+	_, ok = HistoricalDate{YearsAgo: 13.8e9}.Time()
+	assert.False(t, ok)
+}
+
+func TestHistoricalDate_String(t *testing.T) {
+	assert.Equal(t, "July 4, 1776", HistoricalDate{Year: 1776, Month: time.July, Day: 4}.String())
+	assert.Equal(t, "about 3000 BC", HistoricalDate{Year: 3000, Era: BC, Approximate: true}.String())
+	assert.Equal(t, "13800000000 years ago", HistoricalDate{YearsAgo: 13.8e9}.String())
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"unicode/utf8"
+)
+
+// redacted replaces secrets in debug output.
+const redacted = "REDACTED"
+
+// dumpRequest writes the request line and headers to the client's Debug
+// writer in a single Write, with the AppID and secret headers redacted.
+func (c Client) dumpRequest(req *http.Request) {
+	u := *req.URL
+	u.User = nil
+	if q := u.Query(); q.Has("appid") {
+		q.Set("appid", redacted)
+		u.RawQuery = q.Encode()
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "> %s %s\n", req.Method, &u)
+	dumpHeader(&buf, "> ", req.Header, c.secretHeader)
+	fmt.Fprintln(&buf)
+	c.Debug.Write(buf.Bytes())
+}
+
+// dumpResponse writes the response status, headers, and body to the client's
+// Debug writer in a single Write, with secret headers redacted, and returns
+// the response with its body restored. Binary bodies, like images, are
+// summarized rather than written out.
+func (c Client) dumpResponse(resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "< %s\n", resp.Status)
+	dumpHeader(&buf, "< ", resp.Header, c.secretHeader)
+	fmt.Fprintln(&buf)
+	if utf8.Valid(body) {
+		fmt.Fprintf(&buf, "%s\n\n", body)
+	} else {
+		fmt.Fprintf(&buf, "[%d bytes of binary data]\n\n", len(body))
+	}
+	c.Debug.Write(buf.Bytes())
+	return resp, nil
+}
+
+// secretHeader reports whether the header with the given canonical key may
+// hold a secret and must be redacted from debug output: a credential, or any
+// header set in the client's Header, which often carries API keys for
+// gateways and proxies.
+func (c Client) secretHeader(key string) bool {
+	if key == "Authorization" || key == "Proxy-Authorization" {
+		return true
+	}
+	for k := range c.Header {
+		if http.CanonicalHeaderKey(k) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// dumpHeader writes the header in sorted order, one line per value, with the
+// values of secret headers redacted.
+func dumpHeader(w io.Writer, prefix string, h http.Header, secret func(key string) bool) {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range h[key] {
+			if secret(key) {
+				value = redacted
+			}
+			fmt.Fprintf(w, "%s%s: %s\n", prefix, key, value)
+		}
+	}
+}
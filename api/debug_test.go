@@ -0,0 +1,53 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_Debug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/image" {
+			w.Write([]byte{'G', 'I', 'F', 0xff, 0xfe})
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	c := Client{
+		AppID:     "SECRET-ID",
+		Endpoint:  server.URL,
+		UserAgent: "tutor/1.0",
+		Header:    http.Header{"Authorization": {"Bearer token"}, "x-api-key": {"GATEWAY-KEY"}},
+		Debug:     &buf,
+	}
+	answer, err := c.Ask("10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.048 meters", answer)
+
+	dump := buf.String()
+	assert.NotContains(t, dump, "SECRET-ID")
+	assert.NotContains(t, dump, "Bearer token")
+	assert.NotContains(t, dump, "GATEWAY-KEY")
+	assert.Contains(t, dump, "> X-Api-Key: REDACTED\n")
+	assert.Contains(t, dump, "> GET "+server.URL+"?appid=REDACTED&input=10+feet+in+meters\n")
+	assert.Contains(t, dump, "> Authorization: REDACTED\n")
+	assert.Contains(t, dump, "> User-Agent: tutor/1.0\n")
+	assert.Contains(t, dump, "< 200 OK\n")
+	assert.Contains(t, dump, "< Content-Type: text/xml\n")
+	assert.Contains(t, dump, strings.TrimSpace(resultXML))
+
+	buf.Reset()
+	data, err := c.fetch(context.Background(), server.URL+"/image", nil)
+	assert.NoError(t, err)
+	assert.Len(t, data, 5)
+	assert.Contains(t, buf.String(), "[5 bytes of binary data]")
+}
@@ -0,0 +1,54 @@
+package api
+
+import "context"
+
+// basicFormats returns which of the client's formats are plaintext or image,
+// which pods can (almost) always be rendered in, and whether the client
+// requests any other formats. If the client requests neither plaintext nor
+// image, it returns just plaintext.
+func (c Client) basicFormats() (formats []Format, exotic bool) {
+	for _, f := range c.Formats {
+		if f == PlaintextFormat || f == ImageF {
+			formats = append(formats, f)
+		} else {
+			exotic = true
+		}
+	}
+	if len(formats) == 0 {
+		formats = []Format{PlaintextFormat}
+	}
+	return formats, exotic
+}
+
+// downgrade re-requests the result's errored pods without the client's exotic
+// formats (like MathML and image maps), if DowngradeFormats is set, replacing
+// the pods that come back without errors.
+func (c Client) downgrade(ctx context.Context, input string, r *Result) error {
+	formats, exotic := c.basicFormats()
+	if !c.DowngradeFormats || !exotic {
+		return nil
+	}
+	var ids []string
+	for _, pod := range r.Pods {
+		if pod.Errored || pod.HasError() {
+			ids = append(ids, pod.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	basic := c
+	basic.Formats = formats
+	v := basic.values(input)
+	v["includepodid"] = ids
+	retry, err := c.get(ctx, v)
+	if err != nil {
+		return err
+	}
+
+	r.replacePods(retry.Pods, func(pod Pod) bool {
+		return !pod.Errored && !pod.HasError() && len(pod.Subpods) > 0
+	})
+	return nil
+}
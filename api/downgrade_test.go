@@ -0,0 +1,57 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestClient_basicFormats(t *testing.T) {
+	formats, exotic := Client{}.basicFormats()
+	assert.Equal(t, []Format{PlaintextFormat}, formats)
+	assert.False(t, exotic)
+
+	formats, exotic = Client{Formats: []Format{ImageF, MathMLFormat, ImageMapFormat}}.basicFormats()
+	assert.Equal(t, []Format{ImageF}, formats)
+	assert.True(t, exotic)
+
+	formats, exotic = Client{Formats: []Format{MathMLFormat}}.basicFormats()
+	assert.Equal(t, []Format{PlaintextFormat}, formats)
+	assert.True(t, exotic)
+}
+
+func TestClient_Query_downgrade(t *testing.T) {
+	var retries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("includepodid") == "" {
+			w.Write([]byte(`<queryresult success="true">
+			                  <pod id="Input"><subpod><plaintext>x</plaintext></subpod></pod>
+			                  <pod id="Plot" error="true"><error><code>1000</code><msg>MathML failed</msg></error></pod>
+			                </queryresult>`))
+			return
+		}
+		retries = append(retries, r.URL.Query())
+		w.Write([]byte(`<queryresult success="true">
+		                  <pod id="Plot"><subpod><plaintext>y</plaintext></subpod></pod>
+		                </queryresult>`))
+	}))
+	defer server.Close()
+
+	c := Client{Endpoint: server.URL, Formats: []Format{PlaintextFormat, MathMLFormat}}
+	result, err := c.Query("x")
+	assert.NoError(t, err)
+	assert.True(t, result.Pods[1].Errored)
+	assert.Empty(t, retries)
+
+	c.DowngradeFormats = true
+	result, err = c.Query("x")
+	assert.NoError(t, err)
+	assert.False(t, result.Pods[1].Errored)
+	assert.Equal(t, "y", result.Pods[1].Subpods[0].Plaintext)
+	if assert.Len(t, retries, 1) {
+		assert.Equal(t, []string{"Plot"}, retries[0]["includepodid"])
+		assert.Equal(t, "plaintext", retries[0].Get("format"))
+	}
+}
@@ -2,8 +2,11 @@ package api
 
 import (
 	"encoding/xml"
+	"math"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // An Assumption defines a single assumption, typically about the meaning of a
@@ -41,6 +44,10 @@ type AssumptionValue struct {
 	// The internal identifier for the assumption value
 	Name string `xml:"name,attr"`
 
+	// The word or phrase the value applies to, for assumptions covering
+	// several words (like MultiClash)
+	Word string `xml:"word,attr"`
+
 	// A description of the assumption suitable for display to the user
 	Description string `xml:"desc,attr"`
 
@@ -130,8 +137,57 @@ type Image struct {
 	Height int `xml:"height,attr,omitempty"`
 }
 
-// HTML returns an HTML string for displaying the image in a webpage.
+// MaxImageDimension is the largest image width or height, in pixels, that is
+// accepted when decoding an Image. Wolfram Alpha images are far smaller than
+// this in practice, so larger values are treated as corrupt.
+const MaxImageDimension = 10000
+
+// UnmarshalXML decodes an Image, treating negative dimensions and dimensions
+// larger than MaxImageDimension as unknown (zero).
+func (img *Image) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type image Image
+	if err := d.DecodeElement((*image)(img), &start); err != nil {
+		return err
+	}
+	if img.Width < 0 || img.Width > MaxImageDimension {
+		img.Width = 0
+	}
+	if img.Height < 0 || img.Height > MaxImageDimension {
+		img.Height = 0
+	}
+	return nil
+}
+
+// AspectRatio returns the ratio of the image's width to its height, or zero
+// if either dimension is unknown.
+func (img Image) AspectRatio() float64 {
+	if img.Width <= 0 || img.Height <= 0 {
+		return 0
+	}
+	return float64(img.Width) / float64(img.Height)
+}
+
+// ScaledTo returns the image's width and height scaled down, preserving the
+// aspect ratio, to fit within maxWidth pixels. Images that already fit, or
+// whose dimensions are unknown, are returned at their original size.
+func (img Image) ScaledTo(maxWidth int) (width, height int) {
+	if img.Width <= maxWidth || img.Height <= 0 || maxWidth <= 0 {
+		return img.Width, img.Height
+	}
+	height = int(math.Round(float64(img.Height) * float64(maxWidth) / float64(img.Width)))
+	if height < 1 {
+		height = 1
+	}
+	return maxWidth, height
+}
+
+// HTML returns an HTML string for displaying the image in a webpage. The alt
+// and title text are escaped, and the src attribute is left empty unless the
+// image URL is an http, https, or relative URL.
 func (img Image) HTML() string {
+	if !safeURL(img.URL) {
+		img.URL = ""
+	}
 	x, err := xml.Marshal(&img)
 	if err != nil {
 		panic(err)
@@ -209,8 +265,15 @@ type Pod struct {
 	// Whether the pod couldn't be processed
 	Errored bool `xml:"error,attr"`
 
+	// The error, if the pod couldn't be processed
+	Error *Error `xml:"error"`
+
 	// Whether the pod is the query's primary pod
 	Primary bool `xml:"primary,attr"`
+
+	// A URL to fetch the pod's contents from once they're computed, if the
+	// query was made in async mode and the pod wasn't ready (see Client.Async)
+	Async string `xml:"async,attr"`
 }
 
 // A Reinterpretation occurs when Wolfram Alpha cannot understand a query and
@@ -250,8 +313,11 @@ type Result struct {
 	// The result pods
 	Pods []Pod `xml:"pod"`
 
+	// The pods removed by the client's filters, if any (see Filter)
+	Filtered []FilteredPod `xml:"-"`
+
 	// The query assumptions, if any were made
-	Assumptions []Assumption `xml:"assumption"`
+	Assumptions []Assumption `xml:"assumptions>assumption"`
 
 	// The example page, if the query referred to a general topic
 	ExamplePage *ExamplePage `xml:"examplepage"`
@@ -272,7 +338,7 @@ type Result struct {
 	Tips []Tip `xml:"tips>tip"`
 
 	// The sources used to compute the result, if any
-	Sources []Source `xml:"source"`
+	Sources []Source `xml:"sources>source"`
 
 	// Whether the input was understood
 	Succeeded bool `xml:"success,attr"`
@@ -281,11 +347,15 @@ type Result struct {
 	Errored bool `xml:"error,attr"`
 
 	// The error, if the query couldn't be processed
-	Error Error `xml:"error"`
+	Error *Error `xml:"error"`
 
 	// A URL to recalculate the query and get more pods, if there were errors
 	Recalculate string `xml:"recalculate,attr"`
 
+	// The time the result, or its latest recalculation, was received, for
+	// telling when the Recalculate URL expires (see RecalculateHandle)
+	Received time.Time `xml:"-"`
+
 	// A comma-separated list of the types of data represented in the result
 	DataTypes string `xml:"datatypes,attr"`
 
@@ -303,6 +373,25 @@ type Result struct {
 
 	// The API version
 	Version string `xml:"version,attr"`
+
+	// The HTTP response headers retained by the client, for support requests
+	// to Wolfram (see Client.ResponseHeaders)
+	ResponseMeta http.Header `xml:"-"`
+
+	// Warnings about how the query was read, like spelling corrections and
+	// translations
+	Warnings []Warning `xml:"-"`
+
+	// The input the result answers, as sent to Wolfram Alpha (that is, after
+	// scrubbing), if the result came from a query
+	Input string `xml:"-"`
+
+	// The personal data removed from the input before it was sent, if any
+	// (see Scrubber)
+	Redactions []Redaction `xml:"-"`
+
+	// The client and options that sent the query, if the result came from one
+	origin *origin
 }
 
 // A Source provides a link to a web page with source information. Sources are
@@ -343,6 +432,10 @@ type Subpod struct {
 	// The subpod image, if available
 	Image *Image `xml:"img"`
 
+	// Whether the image format was requested but the subpod has no image,
+	// which usually means image generation timed out
+	ImageMissing bool `xml:"-"`
+
 	// The subpod MathML representation, if available
 	MathML *MathML `xml:"mathml"`
 
@@ -352,6 +445,9 @@ type Subpod struct {
 	// The Mathematica output, if available
 	MathematicaOutput string `xml:"moutput"`
 
+	// The Mathematica notebook cell, if the cell format was requested
+	Cell *Cell `xml:"cell"`
+
 	// Whether the subpod is the query's primary subpod
 	Primary bool `xml:"primary,attr"`
 }
@@ -84,6 +84,29 @@ func TestImage(t *testing.T) {
 	}, img)
 }
 
+func TestImage_Dimensions(t *testing.T) {
+	var img Image
+	const imgXML = `<img src="http://wolframalpha.com/53" width="-36" height="1800000"/>`
+	xml.Unmarshal([]byte(imgXML), &img)
+	assert.EqualValues(t, Image{URL: "http://wolframalpha.com/53"}, img)
+}
+
+func TestImage_AspectRatio(t *testing.T) {
+	assert.Equal(t, 2.0, Image{Width: 36, Height: 18}.AspectRatio())
+	assert.Equal(t, 0.0, Image{Width: 36}.AspectRatio())
+}
+
+func TestImage_ScaledTo(t *testing.T) {
+	w, h := Image{Width: 400, Height: 300}.ScaledTo(200)
+	assert.Equal(t, []int{200, 150}, []int{w, h})
+	w, h = Image{Width: 100, Height: 300}.ScaledTo(200)
+	assert.Equal(t, []int{100, 300}, []int{w, h})
+	w, h = Image{Width: 4000, Height: 1}.ScaledTo(200)
+	assert.Equal(t, []int{200, 1}, []int{w, h})
+	w, h = Image{Width: 400}.ScaledTo(200)
+	assert.Equal(t, []int{400, 0}, []int{w, h})
+}
+
 func TestImage_HTML(t *testing.T) {
 	assert.Equal(
 		t,
@@ -120,6 +143,18 @@ func TestImage_HTML(t *testing.T) {
 	)
 }
 
+func TestImage_HTML_unsafe(t *testing.T) {
+	assert.Equal(
+		t,
+		`<img src="" alt="&lt;script&gt;alert(1)&lt;/script&gt;" title="&#34; onload=&#34;alert(1)"/>`,
+		Image{
+			URL:   "javascript:alert(1)",
+			Alt:   "<script>alert(1)</script>",
+			Title: `" onload="alert(1)`,
+		}.HTML(),
+	)
+}
+
 func TestImage_Mime(t *testing.T) {
 	assert.Equal(
 		t,
@@ -172,6 +207,32 @@ func TestSource(t *testing.T) {
 	}, src)
 }
 
+func TestResult_wrappedLists(t *testing.T) {
+	var result Result
+	const wrappedXML = `<queryresult success='true' error='false' numpods='0'>
+	                     <assumptions count='1'>
+	                       <assumption type='Clash' word='pi' count='1'>
+	                         <value name='NamedConstant' desc='a mathematical constant' input='*C.pi-_*NamedConstant-' />
+	                       </assumption>
+	                     </assumptions>
+	                     <sources count='2'>
+	                       <source url='https://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html' text='City data' />
+	                       <source url='https://www.wolframalpha.com/sources/CountryDataSourceInformationNotes.html' text='Country data' />
+	                     </sources>
+	                   </queryresult>`
+	assert.NoError(t, xml.Unmarshal([]byte(wrappedXML), &result))
+	assert.Equal(t, []Assumption{{
+		Type:   "Clash",
+		Word:   "pi",
+		Values: []AssumptionValue{{Name: "NamedConstant", Description: "a mathematical constant", Input: "*C.pi-_*NamedConstant-"}},
+	}}, result.Assumptions)
+	assert.Equal(t, []Source{
+		{URL: "https://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html", Description: "City data"},
+		{URL: "https://www.wolframalpha.com/sources/CountryDataSourceInformationNotes.html", Description: "Country data"},
+	}, result.Sources)
+	assert.Len(t, result.UniqueSources(), 2)
+}
+
 func TestPod(t *testing.T) {
 	var pod Pod
 	const podXML = `<pod title="Input interpretation"
@@ -0,0 +1,270 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxInputLength is the longest input, in characters, that CheckInput
+// accepts.
+const MaxInputLength = 1000
+
+var (
+	// ErrEmptyInput occurs when the input is empty or only whitespace.
+	ErrEmptyInput = errors.New("api: empty input")
+
+	// ErrInputTooLong occurs when the input is longer than MaxInputLength.
+	ErrInputTooLong = errors.New("api: input too long")
+
+	// ErrInvalidCharacter occurs when the input contains a control character
+	// or invalid UTF-8.
+	ErrInvalidCharacter = errors.New("api: invalid character in input")
+)
+
+// An InputError occurs when an input fails client-side validation, before any
+// request is made. Err is one of ErrEmptyInput, ErrInputTooLong, and
+// ErrInvalidCharacter.
+type InputError struct {
+	// The rejected input
+	Input string
+
+	// The byte offset of the problem in the input, if any
+	Offset int
+
+	// The reason the input was rejected
+	Err error
+}
+
+func (e *InputError) Error() string {
+	if e.Err == ErrInvalidCharacter {
+		return fmt.Sprintf("%v at offset %d", e.Err, e.Offset)
+	}
+	return e.Err.Error()
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// CheckInput validates a query input without sending it, returning an
+// *InputError if it is empty or only whitespace, longer than MaxInputLength,
+// or contains control characters or invalid UTF-8. Wolfram Alpha would reject
+// or misinterpret such inputs anyway, so checking first saves quota; forms
+// can also use it to pre-check user input.
+func CheckInput(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return &InputError{Input: input, Err: ErrEmptyInput}
+	}
+	if n := utf8.RuneCountInString(input); n > MaxInputLength {
+		return &InputError{Input: input, Offset: len(input), Err: ErrInputTooLong}
+	}
+	for i, r := range input {
+		if r == utf8.RuneError || unicode.IsControl(r) && r != '\t' && r != '\n' {
+			return &InputError{Input: input, Offset: i, Err: ErrInvalidCharacter}
+		}
+	}
+	return nil
+}
+
+// A NoContentError occurs when a Result has nothing to show the user: either
+// Wolfram Alpha did not understand the query, or it understood the query but
+// returned no pods besides the "Input interpretation" pod.
+//
+// Wolfram Alpha usually offers something in place of an answer. The error
+// carries all of it, so callers can build a helpful "no results" message in
+// one place.
+type NoContentError struct {
+	// The query, as Wolfram Alpha interpreted it, if it was understood
+	Input string
+
+	// Alternative queries, close in spelling or meaning to the original, if any
+	Suggestions []string
+
+	// Tips for the user, if any
+	Tips []Tip
+
+	// The example page, if the query referred to a general topic
+	ExamplePage *ExamplePage
+
+	// The future topic, if the query concerned a topic under development
+	FutureTopic *FutureTopic
+}
+
+func (e *NoContentError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return "api: no results (did you mean " + strconv.Quote(e.Suggestions[0]) + "?)"
+	}
+	return "api: no results"
+}
+
+// Guidance returns a message for the user synthesized from whatever Wolfram
+// Alpha offered in place of an answer, like "Did you mean "mustang moon"?" or
+// "This topic is under development.", or a generic message if it offered
+// nothing.
+func (e *NoContentError) Guidance() string {
+	var msgs []string
+	if e.FutureTopic != nil {
+		msg := e.FutureTopic.Message
+		if msg == "" {
+			msg = "This topic is under development."
+		}
+		if e.FutureTopic.Topic != "" {
+			msg = e.FutureTopic.Topic + ": " + msg
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(e.Suggestions) > 0 {
+		msgs = append(msgs, "Did you mean "+strconv.Quote(e.Suggestions[0])+"?")
+	}
+	if e.ExamplePage != nil && e.ExamplePage.URL != "" {
+		msgs = append(msgs, "See examples at "+e.ExamplePage.URL+".")
+	}
+	if len(e.Tips) > 0 {
+		tips := make([]string, len(e.Tips))
+		for i, tip := range e.Tips {
+			tips[i] = strings.TrimSuffix(tip.Message, ".")
+		}
+		msgs = append(msgs, "Try: "+strings.Join(tips, "; ")+".")
+	}
+	if len(msgs) == 0 {
+		return "Wolfram|Alpha doesn't know how to answer that."
+	}
+	return strings.Join(msgs, " ")
+}
+
+// A TimeoutError occurs when a query takes too long, either because it runs
+// past the client's Timeout (or the timeout set by WithQueryTimeout) or
+// because the caller's context deadline passes.
+type TimeoutError struct {
+	// The timeout that was applied to the query, or zero if the timeout came
+	// from the caller's context deadline
+	Timeout time.Duration
+
+	// The underlying error
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Timeout > 0 {
+		return "api: query timed out after " + e.Timeout.String()
+	}
+	return "api: query timed out"
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// isTimeout reports whether err is a timeout, from a context deadline or from
+// the network.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// A ConfigError occurs when a Client's configuration is invalid, such as when
+// it sets conflicting options.
+type ConfigError struct {
+	// The name of the offending Client field
+	Field string
+
+	// A short message describing the problem
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	return "api: invalid " + e.Field + ": " + e.Message
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("api: error %d: %s", e.Code, e.Message)
+}
+
+// Err returns the error that kept the result from having an answer: the
+// result's Error if the query couldn't be processed, a *NoContentError if
+// there is nothing to show the user, or nil if the result has content.
+func (r Result) Err() error {
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.Errored {
+		return &Error{Message: "unknown error"}
+	}
+
+	content := 0
+	for _, pod := range r.Pods {
+		if pod.ID != "Input" {
+			content++
+		}
+	}
+	if r.Succeeded && content > 0 {
+		return nil
+	}
+	return r.noContent()
+}
+
+// noContent returns a *NoContentError carrying everything the result offers
+// in place of an answer.
+func (r Result) noContent() *NoContentError {
+	return &NoContentError{
+		Input:       r.InputInterpretation(),
+		Suggestions: r.Suggestions,
+		Tips:        r.Tips,
+		ExamplePage: r.ExamplePage,
+		FutureTopic: r.FutureTopic,
+	}
+}
+
+// A DiscrepancyError occurs when a verified query gets two answers that
+// disagree (see Client.AskVerified), which usually means one of them was a
+// transient upstream glitch.
+type DiscrepancyError struct {
+	// The query input
+	Input string
+
+	// The first answer
+	Answer string
+
+	// The answer from the check
+	Check string
+}
+
+func (e *DiscrepancyError) Error() string {
+	return fmt.Sprintf("api: answers to %q disagree: %q and %q", e.Input, e.Answer, e.Check)
+}
+
+// A ParseError occurs when a response can't be decoded. It records where
+// decoding failed and which request it was for, so that failures in
+// production can be diagnosed from logs without re-running the query.
+type ParseError struct {
+	// The byte offset in the response at which decoding failed
+	Offset int64
+
+	// Up to 40 bytes of the response on either side of the offset
+	Snippet string
+
+	// A fingerprint of the request's parameters (excluding the AppID), which
+	// is the same for identical queries
+	Fingerprint string
+
+	// The underlying error, usually an *xml.SyntaxError
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf(
+		"api: parsing response to request %s at offset %d near %q: %v",
+		e.Fingerprint, e.Offset, e.Snippet, e.Err,
+	)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
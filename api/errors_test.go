@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/xml"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestResult_Err(t *testing.T) {
+	err := Result{
+		Errored: true,
+		Error:   &Error{Code: 2, Message: "Appid missing"},
+	}.Err()
+	assert.Equal(t, &Error{Code: 2, Message: "Appid missing"}, err)
+	assert.EqualError(t, err, "api: error 2: Appid missing")
+
+	assert.EqualError(t, Result{Errored: true}.Err(), "api: error 0: unknown error")
+
+	err = Result{
+		Succeeded: true,
+		Pods: []Pod{
+			{ID: "Input", Subpods: []Subpod{{Plaintext: "calculus"}}},
+		},
+		ExamplePage: &ExamplePage{Topic: "Calculus"},
+	}.Err()
+	assert.Equal(t, &NoContentError{
+		Input:       "calculus",
+		ExamplePage: &ExamplePage{Topic: "Calculus"},
+	}, err)
+	assert.EqualError(t, err, "api: no results")
+
+	err = Result{
+		Suggestions: []string{"mustang moon"},
+		Tips:        []Tip{{Message: "Check your spelling and use English"}},
+	}.Err()
+	assert.Equal(t, &NoContentError{
+		Suggestions: []string{"mustang moon"},
+		Tips:        []Tip{{Message: "Check your spelling and use English"}},
+	}, err)
+	assert.EqualError(t, err, `api: no results (did you mean "mustang moon"?)`)
+
+	assert.NoError(t, Result{
+		Succeeded: true,
+		Pods:      []Pod{{ID: "Input"}, {ID: "Result"}},
+	}.Err())
+}
+
+func TestNoContentError_Guidance(t *testing.T) {
+	assert.Equal(
+		t,
+		"Operating Systems: Development of this topic is under investigation...",
+		(&NoContentError{FutureTopic: &FutureTopic{
+			Topic:   "Operating Systems",
+			Message: "Development of this topic is under investigation...",
+		}}).Guidance(),
+	)
+	assert.Equal(
+		t,
+		`Did you mean "mustang moon"? Try: Check your spelling; Use English.`,
+		(&NoContentError{
+			Suggestions: []string{"mustang moon"},
+			Tips:        []Tip{{Message: "Check your spelling"}, {Message: "Use English."}},
+		}).Guidance(),
+	)
+	assert.Equal(
+		t,
+		"See examples at http://www.wolframalpha.com/examples/Calculus-content.html.",
+		(&NoContentError{ExamplePage: &ExamplePage{
+			Topic: "Calculus",
+			URL:   "http://www.wolframalpha.com/examples/Calculus-content.html",
+		}}).Guidance(),
+	)
+	assert.Equal(t, "Wolfram|Alpha doesn't know how to answer that.", (&NoContentError{}).Guidance())
+}
+
+func TestCheckInput(t *testing.T) {
+	assert.NoError(t, CheckInput("population of France"))
+	assert.NoError(t, CheckInput("integrate x^2\tdx"))
+	assert.NoError(t, CheckInput(strings.Repeat("π", MaxInputLength)))
+
+	err := CheckInput(" \t ")
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	assert.EqualError(t, err, "api: empty input")
+
+	err = CheckInput(strings.Repeat("a", MaxInputLength+1))
+	assert.True(t, errors.Is(err, ErrInputTooLong))
+
+	err = CheckInput("pi\x00")
+	assert.Equal(t, &InputError{Input: "pi\x00", Offset: 2, Err: ErrInvalidCharacter}, err)
+	assert.EqualError(t, err, "api: invalid character in input at offset 2")
+
+	err = CheckInput("pi\xff")
+	assert.True(t, errors.Is(err, ErrInvalidCharacter))
+}
+
+func TestParseError(t *testing.T) {
+	serve(t, `<queryresult success="true"><pod id="Input"></queryresult>`)
+	_, err := Client{AppID: "XXXX"}.Query("pi")
+	var parseErr *ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, int64(58), parseErr.Offset)
+		assert.Equal(t, `ss="true"><pod id="Input"></queryresult>`, parseErr.Snippet)
+		assert.Equal(t, Fingerprint(url.Values{"input": {"pi"}}), parseErr.Fingerprint)
+		assert.Len(t, parseErr.Fingerprint, 16)
+	}
+	var syntaxErr *xml.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+
+	_, err2 := Client{AppID: "YYYY"}.Query("pi")
+	assert.Equal(t, parseErr.Fingerprint, err2.(*ParseError).Fingerprint)
+	assert.EqualError(t, err, "api: parsing response to request "+parseErr.Fingerprint+` at offset 58 near "ss=\"true\"><pod id=\"Input\"></queryresult>": XML syntax error on line 1: element <pod> closed by </queryresult>`)
+}
@@ -0,0 +1,20 @@
+package api_test
+
+import (
+	"bytes"
+	"github.com/hollingberry/wolfram/api"
+	"os/exec"
+	"strings"
+)
+
+// This example adapts the Tesseract command-line OCR engine into a
+// Recognizer, so that image-only answers get plaintext.
+func ExampleRecognizerFunc() {
+	client := api.NewClient("APPID")
+	client.Recognizer = api.RecognizerFunc(func(image []byte) (string, error) {
+		cmd := exec.Command("tesseract", "stdin", "stdout")
+		cmd.Stdin = bytes.NewReader(image)
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	})
+}
@@ -0,0 +1,147 @@
+package api
+
+import "strings"
+
+// A Filter decides which pods may be shown, for deployments (like tutoring
+// apps in schools) that must keep some kinds of content away from their
+// users. When a Client has Filters, QueryContext removes every pod that any of
+// them rejects from the result, and records which pods were removed, and why,
+// in Result.Filtered.
+type Filter interface {
+	// Allow reports whether the pod, which is part of the result, may be
+	// shown.
+	Allow(r Result, pod Pod) bool
+}
+
+// The FilterFunc type is an adapter to allow the use of ordinary functions
+// (like a call out to a custom content classifier) as Filters.
+type FilterFunc func(r Result, pod Pod) bool
+
+// Allow calls f(r, pod).
+func (f FilterFunc) Allow(r Result, pod Pod) bool {
+	return f(r, pod)
+}
+
+// A CategoryFilter suppresses pods in sensitive categories, identified by the
+// scanner that produced the pod or by the data types of the whole result.
+// Names are matched case-insensitively. The Input interpretation pod is always
+// allowed, since it only restates the query.
+type CategoryFilter struct {
+	// The scanners whose pods are suppressed, like "Drug"
+	Scanners []string
+
+	// The result data types for which all pods are suppressed, like "Drug"
+	DataTypes []string
+}
+
+// A FilteredPod records a pod removed from a result by a client's filters.
+// It identifies the pod without carrying any of its content.
+type FilteredPod struct {
+	// The pod ID
+	ID string
+
+	// The pod title
+	Title string
+
+	// Why the pod was removed, like "scanner Drug"
+	Reason string
+}
+
+// A Reasoner is a Filter that can explain its rejections. The explanation is
+// recorded as FilteredPod.Reason; pods rejected by filters that aren't
+// Reasoners get the reason "filtered".
+type Reasoner interface {
+	Filter
+
+	// Reason returns why the pod, which Allow rejected, isn't allowed.
+	Reason(r Result, pod Pod) string
+}
+
+// MinorsFilter is a CategoryFilter suppressing the categories most
+// deployments for minors must block: pharmaceutical data (such as drug
+// dosages) and data about alcohol, tobacco, and sexuality. The lists can't be
+// exhaustive, so deployments with strict requirements should add a custom
+// classifier with FilterFunc.
+var MinorsFilter = CategoryFilter{
+	Scanners: []string{"Drug", "Pharmaceutical"},
+	DataTypes: []string{
+		"Drug", "DrugClass", "Pharmaceutical", "AlcoholicBeverage",
+		"Tobacco", "Sexuality",
+	},
+}
+
+// Allow reports whether the pod is outside the filter's categories.
+func (f CategoryFilter) Allow(r Result, pod Pod) bool {
+	if pod.ID == "Input" {
+		return true
+	}
+	for _, scanner := range f.Scanners {
+		if strings.EqualFold(pod.Scanner, scanner) {
+			return false
+		}
+	}
+	for _, dataType := range strings.Split(r.DataTypes, ",") {
+		for _, blocked := range f.DataTypes {
+			if strings.EqualFold(strings.TrimSpace(dataType), blocked) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Reason returns which of the filter's scanners or data types the pod or
+// result belongs to, like "scanner Drug" or "data type Tobacco".
+func (f CategoryFilter) Reason(r Result, pod Pod) string {
+	for _, scanner := range f.Scanners {
+		if strings.EqualFold(pod.Scanner, scanner) {
+			return "scanner " + scanner
+		}
+	}
+	for _, dataType := range strings.Split(r.DataTypes, ",") {
+		for _, blocked := range f.DataTypes {
+			if strings.EqualFold(strings.TrimSpace(dataType), blocked) {
+				return "data type " + blocked
+			}
+		}
+	}
+	return "filtered"
+}
+
+// filter removes the pods rejected by any of the client's filters from the
+// result, recording them in r.Filtered.
+func (c Client) filter(r *Result) {
+	r.Pods = c.filterPods(r, r.Pods)
+}
+
+// filterPods returns the pods (belonging to the result) that the client's
+// filters allow, recording the others in r.Filtered.
+func (c Client) filterPods(r *Result, pods []Pod) []Pod {
+	if len(c.Filters) == 0 {
+		return pods
+	}
+	allowed := pods[:0:0]
+	for _, pod := range pods {
+		if reason, ok := c.rejection(*r, pod); ok {
+			r.Filtered = append(r.Filtered, FilteredPod{ID: pod.ID, Title: pod.Title, Reason: reason})
+		} else {
+			allowed = append(allowed, pod)
+		}
+	}
+	return allowed
+}
+
+// rejection returns why the first of the client's filters to reject the pod
+// does so, or false if they all allow it.
+func (c Client) rejection(r Result, pod Pod) (string, bool) {
+	for _, f := range c.Filters {
+		if f.Allow(r, pod) {
+			continue
+		}
+		if reasoner, ok := f.(Reasoner); ok {
+			return reasoner.Reason(r, pod), true
+		}
+		return "filtered", true
+	}
+	return "", false
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCategoryFilter_Allow(t *testing.T) {
+	f := CategoryFilter{Scanners: []string{"Drug"}, DataTypes: []string{"AlcoholicBeverage"}}
+	assert.True(t, f.Allow(Result{}, Pod{ID: "Input", Scanner: "Drug"}))
+	assert.False(t, f.Allow(Result{}, Pod{ID: "Dosage", Scanner: "drug"}))
+	assert.True(t, f.Allow(Result{}, Pod{ID: "Result", Scanner: "Simplification"}))
+	assert.False(t, f.Allow(Result{DataTypes: "Beverage, AlcoholicBeverage"}, Pod{ID: "Result"}))
+	assert.True(t, f.Allow(Result{DataTypes: "Beverage"}, Pod{ID: "Result"}))
+
+	assert.Equal(t, "scanner Drug", f.Reason(Result{}, Pod{ID: "Dosage", Scanner: "drug"}))
+	assert.Equal(t, "data type AlcoholicBeverage", f.Reason(Result{DataTypes: "Beverage, AlcoholicBeverage"}, Pod{ID: "Result"}))
+}
+
+func TestClient_Query_filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<queryresult success="true">
+		                  <pod id="Input"><subpod><plaintext>ibuprofen</plaintext></subpod></pod>
+		                  <pod id="Dosage" scanner="Drug"><subpod><plaintext>200 mg</plaintext></subpod></pod>
+		                  <pod id="Structure" scanner="Data"><subpod><plaintext>C13H18O2</plaintext></subpod></pod>
+		                </queryresult>`))
+	}))
+	defer server.Close()
+
+	noFormulas := FilterFunc(func(r Result, pod Pod) bool { return pod.ID != "Structure" })
+	c := Client{Endpoint: server.URL, Filters: []Filter{MinorsFilter, noFormulas}}
+	result, err := c.Query("ibuprofen")
+	assert.NoError(t, err)
+	if assert.Len(t, result.Pods, 1) {
+		assert.Equal(t, "Input", result.Pods[0].ID)
+	}
+	assert.Equal(t, []FilteredPod{
+		{ID: "Dosage", Reason: "scanner Drug"},
+		{ID: "Structure", Reason: "filtered"},
+	}, result.Filtered)
+	assert.IsType(t, &NoContentError{}, result.Err())
+}
@@ -0,0 +1,71 @@
+package api
+
+import "context"
+
+// requestsImages reports whether the client requests the image format, which
+// Wolfram Alpha returns by default when no formats are given.
+func (c Client) requestsImages() bool {
+	if len(c.Formats) == 0 {
+		return true
+	}
+	for _, f := range c.Formats {
+		if f == ImageF {
+			return true
+		}
+	}
+	return false
+}
+
+// flagMissingImages sets ImageMissing on the result's subpods that have no
+// image even though the client requests images, and returns the IDs of the
+// pods containing them.
+func (c Client) flagMissingImages(r *Result) []string {
+	var ids []string
+	if !c.requestsImages() {
+		return nil
+	}
+	for i := range r.Pods {
+		missing := false
+		for j := range r.Pods[i].Subpods {
+			subpod := &r.Pods[i].Subpods[j]
+			subpod.ImageMissing = subpod.Image == nil
+			missing = missing || subpod.ImageMissing
+		}
+		if missing {
+			ids = append(ids, r.Pods[i].ID)
+		}
+	}
+	return ids
+}
+
+// retryImages flags subpods with missing images and, if the client has an
+// ImageRetryTimeout, re-requests just their pods with that format timeout,
+// replacing the pods that come back with images.
+func (c Client) retryImages(ctx context.Context, input string, r *Result) error {
+	ids := c.flagMissingImages(r)
+	if len(ids) == 0 || c.ImageRetryTimeout == 0 {
+		return nil
+	}
+
+	v := c.values(input)
+	v["includepodid"] = ids
+	v.Set("formattimeout", seconds(c.ImageRetryTimeout))
+	retry, err := c.get(ctx, v)
+	if err != nil {
+		return err
+	}
+	c.flagMissingImages(&retry)
+
+	r.replacePods(retry.Pods, hasImage)
+	return nil
+}
+
+// hasImage reports whether any of the pod's subpods has an image.
+func hasImage(pod Pod) bool {
+	for _, subpod := range pod.Subpods {
+		if subpod.Image != nil && subpod.Image.URL != "" {
+			return true
+		}
+	}
+	return false
+}
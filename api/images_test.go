@@ -0,0 +1,70 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestClient_flagMissingImages(t *testing.T) {
+	result := Result{Pods: []Pod{
+		{ID: "Input", Subpods: []Subpod{{Image: &Image{}}}},
+		{ID: "Result", Subpods: []Subpod{{Image: &Image{}}, {}}},
+	}}
+	assert.Equal(t, []string{"Result"}, Client{}.flagMissingImages(&result))
+	assert.False(t, result.Pods[1].Subpods[0].ImageMissing)
+	assert.True(t, result.Pods[1].Subpods[1].ImageMissing)
+
+	result.Pods[1].Subpods[1].ImageMissing = false
+	assert.Nil(t, Client{Formats: []Format{PlaintextFormat}}.flagMissingImages(&result))
+	assert.False(t, result.Pods[1].Subpods[1].ImageMissing)
+}
+
+func TestClient_Query_retryImages(t *testing.T) {
+	var retries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("formattimeout") == "" {
+			w.Write([]byte(`<queryresult success="true">
+			                  <pod id="Input"><subpod><img src="http://wolframalpha.com/1"/></subpod></pod>
+			                  <pod id="Result"><subpod><plaintext>x</plaintext></subpod></pod>
+			                </queryresult>`))
+			return
+		}
+		retries = append(retries, r.URL.Query())
+		if r.URL.Query().Get("formattimeout") == "1" {
+			w.Write([]byte(`<queryresult success="true">
+			                  <pod id="Result" title="retried"><subpod><plaintext>x</plaintext></subpod></pod>
+			                </queryresult>`))
+			return
+		}
+		w.Write([]byte(`<queryresult success="true">
+		                  <pod id="Result"><subpod><plaintext>x</plaintext><img src="http://wolframalpha.com/2"/></subpod></pod>
+		                </queryresult>`))
+	}))
+	defer server.Close()
+	orig := queryURL
+	queryURL = server.URL
+	defer func() { queryURL = orig }()
+
+	result, err := Client{}.Query("x")
+	assert.NoError(t, err)
+	assert.True(t, result.Pods[1].Subpods[0].ImageMissing)
+	assert.Empty(t, retries)
+
+	result, err = Client{ImageRetryTimeout: 8500 * time.Millisecond}.Query("x")
+	assert.NoError(t, err)
+	assert.False(t, result.Pods[1].Subpods[0].ImageMissing)
+	assert.Equal(t, &Image{URL: "http://wolframalpha.com/2"}, result.Pods[1].Subpods[0].Image)
+	assert.Len(t, retries, 1)
+	assert.Equal(t, []string{"Result"}, retries[0]["includepodid"])
+	assert.Equal(t, "8.5", retries[0].Get("formattimeout"))
+
+	result, err = Client{ImageRetryTimeout: time.Second}.Query("x")
+	assert.NoError(t, err)
+	assert.Empty(t, result.Pods[1].Title)
+	assert.True(t, result.Pods[1].Subpods[0].ImageMissing)
+	assert.Len(t, retries, 2)
+}
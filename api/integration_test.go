@@ -0,0 +1,202 @@
+//go:build integration
+
+package api
+
+// The integration tests query the live Wolfram Alpha API, to verify the
+// package against real responses before a release. They only build with the
+// integration tag, and need an AppID:
+//
+//	WOLFRAM_APP_ID=... go test -tags integration ./api
+//
+// Each test reserves the requests it may make from a budget (25 by default,
+// or WOLFRAM_BUDGET), and is skipped once the budget is spent, so a run can't
+// consume more of the AppID's quota than that.
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var budget struct {
+	sync.Mutex
+	once sync.Once
+	left int
+}
+
+// liveClient returns a client for the live API that may make at most the
+// given number of requests, reserving them from the run's budget. It skips
+// the test if there's no AppID or the budget is spent.
+func liveClient(t *testing.T, requests int) Client {
+	t.Helper()
+	appID := liveAppID
+	if appID == "" {
+		t.Skip("WOLFRAM_APP_ID is not set")
+	}
+
+	budget.Lock()
+	budget.once.Do(func() {
+		budget.left = 25
+		if n, err := strconv.Atoi(os.Getenv("WOLFRAM_BUDGET")); err == nil {
+			budget.left = n
+		}
+	})
+	if budget.left < requests {
+		budget.Unlock()
+		t.Skipf("query budget spent (%d left, %d needed)", budget.left, requests)
+	}
+	budget.left -= requests
+	budget.Unlock()
+
+	c := NewClient(appID)
+	c.Timeout = 30 * time.Second
+	// The budget is enforced by the transport rather than an interceptor, so
+	// that it covers secondary fetches (async pods, recalculations, images),
+	// which don't run the client's interceptors.
+	c.HTTPClient = &http.Client{Transport: &budgetTransport{left: requests, next: http.DefaultTransport}}
+	return c
+}
+
+// A budgetTransport refuses to send more than a fixed number of requests.
+type budgetTransport struct {
+	mu   sync.Mutex
+	left int
+	next http.RoundTripper
+}
+
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.left--
+	left := t.left
+	t.mu.Unlock()
+	if left < 0 {
+		return nil, errors.New("integration test exceeded its request budget")
+	}
+	return t.next.RoundTrip(req)
+}
+
+func TestLive_Query(t *testing.T) {
+	c := liveClient(t, 1)
+	c.Formats = []Format{PlaintextFormat, ImageF, MathMLFormat}
+	c.Units = Metric
+	r, err := c.Query("10 feet in meters")
+	assert.NoError(t, err)
+	assert.True(t, r.Succeeded)
+	assert.NoError(t, r.Err())
+	assert.Equal(t, "10 feet in meters", r.Input)
+	assert.NotEmpty(t, r.InputInterpretation())
+
+	var primary *Subpod
+	for _, pod := range r.Pods {
+		if pod.Primary {
+			primary = &pod.Subpods[0]
+		}
+	}
+	if assert.NotNil(t, primary) {
+		assert.Contains(t, primary.Plaintext, "3.048")
+		assert.True(t, primary.HasImage())
+		assert.Equal(t, QuantityKind, primary.Kind())
+	}
+}
+
+func TestLive_QueryParameters(t *testing.T) {
+	c := liveClient(t, 1)
+	c.IncludePodIDs = []string{"Result"}
+	c.ScanTimeout = 3 * time.Second
+	c.PodTimeout = 4 * time.Second
+	c.FormatTimeout = 8 * time.Second
+	c.ParseTimeout = 5 * time.Second
+	c.Location = "Boston, MA"
+	c.IgnoreCase = true
+	r, err := c.Query("distance to New York")
+	assert.NoError(t, err)
+	assert.True(t, r.Succeeded)
+	for _, pod := range r.Pods {
+		assert.Equal(t, "Result", pod.ID)
+	}
+}
+
+func TestLive_Reinterpret(t *testing.T) {
+	c := liveClient(t, 1)
+	c.Reinterpret = ToggleOn
+	r, err := c.Query("kitty danger")
+	assert.NoError(t, err)
+	assert.True(t, r.Succeeded)
+}
+
+func TestLive_Translation(t *testing.T) {
+	c := liveClient(t, 1)
+	c.Translation = true
+	r, err := c.Query("wie hoch ist der Eiffelturm")
+	assert.NoError(t, err)
+	if w := r.Translated(); assert.NotNil(t, w) {
+		assert.Equal(t, "German", w.Language)
+	}
+}
+
+func TestLive_Assumptions(t *testing.T) {
+	c := liveClient(t, 2)
+	r, err := c.Query("mercury")
+	assert.NoError(t, err)
+	interps := r.Interpretations()
+	if !assert.True(t, len(interps) > 1) {
+		return
+	}
+	next, err := c.ApplyAssumption(r, interps[1].Values[0])
+	assert.NoError(t, err)
+	assert.NotEmpty(t, AssumptionDiff(r, next))
+}
+
+func TestLive_Async(t *testing.T) {
+	c := liveClient(t, 6)
+	c.Async = true
+	r, err := c.Query("weather in Chicago")
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	assert.NoError(t, c.FetchAsyncPods(ctx, &r))
+	assert.Empty(t, r.AsyncPods())
+}
+
+func TestLive_Recalculate(t *testing.T) {
+	c := liveClient(t, 3)
+	c.PodTimeout = 100 * time.Millisecond
+	c.RecalculateBudget = 2
+	r, err := c.Query("population of France")
+	assert.NoError(t, err)
+	assert.True(t, r.Succeeded)
+}
+
+func TestLive_Ask(t *testing.T) {
+	c := liveClient(t, 2)
+	c.VerifyShortAnswers = true
+	answer, err := c.AskVerified("speed of light in km/s")
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(answer, "299") || strings.Contains(answer, "300"))
+}
+
+func TestLive_Validate(t *testing.T) {
+	c := liveClient(t, 2)
+	v, err := c.Validate("pi")
+	assert.NoError(t, err)
+	assert.True(t, v.Success)
+
+	v, err = c.Validate("fjqpwoeiruty")
+	assert.NoError(t, err)
+	assert.False(t, v.Success)
+}
+
+func TestLive_Error(t *testing.T) {
+	c := liveClient(t, 1)
+	c.AppID = "INVALID"
+	_, err := c.Query("pi")
+	var apiErr *Error
+	assert.True(t, errors.As(err, &apiErr), "error %v", err)
+}
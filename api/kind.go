@@ -0,0 +1,90 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A PlaintextKind classifies what a subpod's plaintext holds, to choose how
+// to render it and which helper to extract typed values with. See
+// Subpod.Kind.
+type PlaintextKind int
+
+const (
+	// Free text, like a definition or description (or no plaintext at all)
+	ProseKind PlaintextKind = iota
+
+	// A bare number, like "3.14159" or "1.2×10^6" (see ParseNumber)
+	NumberKind
+
+	// A number with a unit, like "3.048 meters" (see CleanPlaintext)
+	QuantityKind
+
+	// A date, a range of dates, or a relative date, like "July 4, 1776" or
+	// "13.8 billion years ago" (see ParseHistoricalDate and ParseDateSpan)
+	DateKind
+
+	// A table, with one row per line and columns separated by " | "
+	TableKind
+
+	// A list of items, one per line, separated by " | ", or in braces like
+	// "{1, 2, 3}"
+	ListKind
+)
+
+var plaintextKindNames = [...]string{
+	ProseKind:    "prose",
+	NumberKind:   "number",
+	QuantityKind: "quantity",
+	DateKind:     "date",
+	TableKind:    "table",
+	ListKind:     "list",
+}
+
+// String returns the kind's name, like "quantity".
+func (k PlaintextKind) String() string {
+	if k < 0 || int(k) >= len(plaintextKindNames) {
+		return "PlaintextKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return plaintextKindNames[k]
+}
+
+// Kind guesses what the subpod's plaintext holds. The guess is heuristic:
+// tables and lists are recognized by their layout, and numbers, dates, and
+// quantities by whether the package's parsers accept them, in that order.
+// Anything else is prose.
+func (s Subpod) Kind() PlaintextKind {
+	text := strings.TrimSpace(s.Plaintext)
+	lines := strings.Split(text, "\n")
+	switch {
+	case text == "":
+		return ProseKind
+	case len(lines) > 1:
+		for _, line := range lines {
+			if !strings.Contains(line, " | ") {
+				return ListKind
+			}
+		}
+		return TableKind
+	case strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}"),
+		strings.Count(text, " | ") >= 2:
+		return ListKind
+	}
+
+	if _, err := ParseNumber(strings.TrimSuffix(text, "..."), Locale{}); err == nil {
+		return NumberKind
+	}
+	if strings.IndexFunc(text, unicode.IsLetter) >= 0 {
+		if _, err := ParseHistoricalDate(text); err == nil {
+			return DateKind
+		}
+		if _, err := ParseDateSpan(text); err == nil {
+			return DateKind
+		}
+	}
+	if m := quantityPattern.FindStringSubmatch(unitNamePattern.ReplaceAllString(text, "")); m != nil && len(strings.Fields(m[1])) <= 3 {
+		return QuantityKind
+	}
+	return ProseKind
+}
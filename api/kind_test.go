@@ -0,0 +1,36 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSubpod_Kind(t *testing.T) {
+	for plaintext, kind := range map[string]PlaintextKind{
+		"":                                     ProseKind,
+		"3.14159265358979...":                  NumberKind,
+		"−42":                                  NumberKind,
+		"1,234,567":                            NumberKind,
+		"1776":                                 NumberKind,
+		"3.048 meters":                         QuantityKind,
+		"8.4 million people":                   QuantityKind,
+		"299792458 m/s (meters per second)":    QuantityKind,
+		"Thursday, July 4, 1776":               DateKind,
+		"13.8 billion years ago":               DateKind,
+		"July 4, 1776 to September 3, 1783":    DateKind,
+		"name | symbol\nhydrogen | H":          TableKind,
+		"x = -1\nx = 1":                        ListKind,
+		"{1, 2, 3}":                            ListKind,
+		"Ceres | Pluto | Eris":                 ListKind,
+		"a large, round, orange fruit":         ProseKind,
+		"population | 8.4 million people":      ProseKind,
+		"the 3 largest moons of Jupiter today": ProseKind,
+	} {
+		assert.Equal(t, kind, Subpod{Plaintext: plaintext}.Kind(), plaintext)
+	}
+}
+
+func TestPlaintextKind_String(t *testing.T) {
+	assert.Equal(t, "quantity", QuantityKind.String())
+	assert.Equal(t, "PlaintextKind(9)", PlaintextKind(9).String())
+}
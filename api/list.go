@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrNotList occurs when plaintext that should be a list has fewer than
+	// two items.
+	ErrNotList = errors.New("api: not a list")
+
+	// ErrNotTable occurs when plaintext that should be a table has fewer than
+	// two columns.
+	ErrNotTable = errors.New("api: not a table")
+)
+
+// List splits the subpod's plaintext into list items, for answers like
+// "Mercury | Venus | Earth | Mars" to queries like "planets of the solar
+// system". Items may be separated by newlines or " | ", or written in braces
+// like "{Mercury, Venus}". Separators inside parentheses and brackets, as in
+// "Pluto (dwarf planet | former planet)", don't split items, and a trailing
+// "..." marking a truncated list is dropped. It returns ErrNotList if there
+// are fewer than two items.
+func (s Subpod) List() ([]string, error) {
+	text := strings.TrimSpace(s.Plaintext)
+	sep := " | "
+	switch {
+	case strings.Contains(text, "\n"):
+		sep = "\n"
+	case strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}"):
+		text, sep = text[1:len(text)-1], ","
+	}
+
+	var items []string
+	for _, item := range splitTopLevel(text, sep) {
+		if item = strings.TrimSpace(item); item != "" && item != "..." {
+			items = append(items, item)
+		}
+	}
+	if len(items) < 2 {
+		return nil, ErrNotList
+	}
+	return items, nil
+}
+
+// PrimaryList splits the answer (the subpod Ask would return) into list
+// items. See Subpod.List.
+func (r Result) PrimaryList() ([]string, error) {
+	answer := r.answer()
+	if answer == nil {
+		return nil, r.noContent()
+	}
+	return answer.List()
+}
+
+// splitTopLevel splits s around each instance of sep that isn't inside
+// parentheses, brackets, or braces.
+func splitTopLevel(s, sep string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		default:
+			if depth == 0 && strings.HasPrefix(s[i:], sep) {
+				parts = append(parts, s[start:i])
+				start = i + len(sep)
+				i = start - 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// Table splits the subpod's plaintext into rows (one per line) and columns
+// (separated by " | "), for tables like "country | area\nRussia | 6.602
+// million mi^2". As with List, separators inside parentheses don't split
+// columns. It returns an error unless there are at least two columns.
+func (s Subpod) Table() ([][]string, error) {
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimSpace(s.Plaintext), "\n") {
+		row := splitTopLevel(line, " | ")
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+		rows = append(rows, row)
+	}
+	if len(rows[0]) < 2 {
+		return nil, ErrNotTable
+	}
+	return rows, nil
+}
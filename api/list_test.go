@@ -0,0 +1,47 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSubpod_List(t *testing.T) {
+	for plaintext, items := range map[string][]string{
+		"Mercury | Venus | Earth | Mars":               {"Mercury", "Venus", "Earth", "Mars"},
+		"Mercury\nVenus\nEarth":                        {"Mercury", "Venus", "Earth"},
+		"{Mercury (planet), Venus, Earth}":             {"Mercury (planet)", "Venus", "Earth"},
+		"Ceres | Pluto (dwarf planet | former) | Eris": {"Ceres", "Pluto (dwarf planet | former)", "Eris"},
+		"Mercury | Venus | ...":                        {"Mercury", "Venus"},
+		"{1, {2, 3}, 4}":                               {"1", "{2, 3}", "4"},
+	} {
+		list, err := Subpod{Plaintext: plaintext}.List()
+		assert.NoError(t, err, plaintext)
+		assert.Equal(t, items, list, plaintext)
+	}
+
+	_, err := Subpod{Plaintext: "3.048 meters"}.List()
+	assert.Equal(t, ErrNotList, err)
+	_, err = Subpod{}.List()
+	assert.Equal(t, ErrNotList, err)
+}
+
+func TestResult_PrimaryList(t *testing.T) {
+	list, err := Result{Pods: []Pod{
+		{ID: "Input", Subpods: []Subpod{{Plaintext: "planets"}}},
+		{ID: "Result", Primary: true, Subpods: []Subpod{{Plaintext: "Mercury | Venus"}}},
+	}}.PrimaryList()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercury", "Venus"}, list)
+
+	_, err = Result{Pods: []Pod{{ID: "Input"}}}.PrimaryList()
+	assert.IsType(t, &NoContentError{}, err)
+}
+
+func TestSubpod_Table(t *testing.T) {
+	table, err := Subpod{Plaintext: "name | symbol\nhydrogen | H (gas | diatomic)"}.Table()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"name", "symbol"}, {"hydrogen", "H (gas | diatomic)"}}, table)
+
+	_, err = Subpod{Plaintext: "hydrogen"}.Table()
+	assert.Equal(t, ErrNotTable, err)
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A Locale defines the separators used to write numbers in plaintext. The
+// zero Locale guesses the separators from each number (see ParseNumber).
+type Locale struct {
+	// The digit grouping separator, like ',' in "1,234,567"
+	Group rune
+
+	// The decimal separator, like '.' in "3.14"
+	Decimal rune
+}
+
+var (
+	// Numbers like "1,234,567.89"
+	LocaleEnglish = Locale{Group: ',', Decimal: '.'}
+
+	// Numbers like "1.234.567,89"
+	LocaleGerman = Locale{Group: '.', Decimal: ','}
+
+	// Numbers like "1 234 567,89"
+	LocaleFrench = Locale{Group: ' ', Decimal: ','}
+
+	// Numbers like "1'234'567.89"
+	LocaleSwiss = Locale{Group: '\'', Decimal: '.'}
+)
+
+// ParseNumber parses a number written with the locale's separators, like
+// "1,234,567" or "1.234.567,89". A leading sign (including the Unicode minus
+// "−") and a trailing power of ten ("×10^8") are allowed. Any kind of space
+// (including non-breaking and thin spaces) matches a space group separator.
+//
+// If locale is the zero Locale, the separators are guessed. When both ',' and
+// '.' appear, the last one is the decimal separator. A lone ',' is a group
+// separator if it occurs more than once or is followed by exactly three
+// digits, and the decimal separator otherwise. A lone '.' is the decimal
+// separator unless it occurs more than once, as Wolfram Alpha plaintext
+// normally writes numbers like "1.609".
+func ParseNumber(s string, locale Locale) (float64, error) {
+	s = strings.TrimSpace(s)
+	invalid := errors.New("api: invalid number: " + s)
+
+	var exp string
+	if i := strings.Index(s, "×10^"); i >= 0 {
+		exp, s = s[i+len("×10^"):], strings.TrimSpace(s[:i])
+	}
+
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "−"):
+		neg, s = true, s[len("−"):]
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	if locale == (Locale{}) {
+		locale = guessLocale(s)
+	}
+
+	// Split the integer part into groups, and check that every group after
+	// the first has exactly three digits.
+	intPart, fracPart := s, ""
+	if i := strings.LastIndex(s, string(locale.Decimal)); i >= 0 {
+		intPart, fracPart = s[:i], s[i+len(string(locale.Decimal)):]
+	}
+	groups := strings.FieldsFunc(intPart, func(r rune) bool {
+		if unicode.IsSpace(locale.Group) {
+			return unicode.IsSpace(r)
+		}
+		return r == locale.Group
+	})
+	if len(groups) == 0 || strings.Join(groups, "") == "" {
+		return 0, invalid
+	}
+	for i, group := range groups {
+		if !isDigits(group) || (i > 0 && len(group) != 3) {
+			return 0, invalid
+		}
+	}
+	if fracPart != "" && !isDigits(fracPart) {
+		return 0, invalid
+	}
+
+	digits := strings.Join(groups, "")
+	if fracPart != "" {
+		digits += "." + fracPart
+	}
+	if exp != "" {
+		digits += "e" + strings.Replace(exp, "−", "-", 1)
+	}
+	n, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	if neg {
+		n = -n
+	}
+	return n, nil
+}
+
+// guessLocale guesses the separators used to write the unsigned number s.
+func guessLocale(s string) Locale {
+	comma, dot := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	switch {
+	case comma >= 0 && dot >= 0:
+		if comma > dot {
+			return LocaleGerman
+		}
+		return LocaleEnglish
+	case comma >= 0:
+		if strings.Count(s, ",") > 1 || len(s)-comma-1 == 3 {
+			return LocaleEnglish
+		}
+		return LocaleGerman
+	case strings.Count(s, ".") > 1:
+		return LocaleGerman
+	case strings.IndexFunc(s, unicode.IsSpace) >= 0:
+		return LocaleFrench
+	}
+	return LocaleEnglish
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// A Quantity is a number with a unit, like "6.602 million mi^2".
+type Quantity struct {
+	// The number, with any magnitude word applied (so "6.602 million" is
+	// 6.602e6)
+	Value float64
+
+	// The unit abbreviation, like "mi^2", or empty for counts
+	Unit string
+}
+
+var magnitudes = map[string]float64{
+	"thousand": 1e3, "million": 1e6, "billion": 1e9, "trillion": 1e12,
+}
+
+// ParseQuantity parses a quantity from Wolfram Alpha plaintext, like
+// "3.048 meters", "6.602 million mi^2 (square miles)", or "8.4 million
+// people". A parenthesized unit name is dropped, and a magnitude word
+// ("thousand", "million", "billion", or "trillion") scales the value. The
+// number is parsed with ParseNumber, guessing the locale.
+func ParseQuantity(s string) (Quantity, error) {
+	s = unitNamePattern.ReplaceAllString(strings.TrimSpace(s), "")
+	number, unit := s, ""
+	if m := quantityPattern.FindStringSubmatchIndex(s); m != nil {
+		number, unit = strings.TrimSpace(s[:m[2]]), s[m[2]:]
+	}
+	value, err := ParseNumber(strings.TrimPrefix(number, "~"), Locale{})
+	if err != nil {
+		return Quantity{}, errors.New("api: invalid quantity: " + s)
+	}
+
+	fields := strings.Fields(unit)
+	if len(fields) > 0 {
+		if m, ok := magnitudes[fields[0]]; ok {
+			value *= m
+			fields = fields[1:]
+		}
+	}
+	return Quantity{Value: value, Unit: strings.Join(fields, " ")}, nil
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		s        string
+		locale   Locale
+		expected float64
+	}{
+		{"1,234,567", LocaleEnglish, 1234567},
+		{"1,234,567.89", LocaleEnglish, 1234567.89},
+		{"1.234.567,89", LocaleGerman, 1234567.89},
+		{"1 234 567,89", LocaleFrench, 1234567.89},
+		{"1 234,5", LocaleFrench, 1234.5},
+		{"1'234'567.89", LocaleSwiss, 1234567.89},
+		{"−42", LocaleEnglish, -42},
+		{"+0.5", LocaleEnglish, 0.5},
+		{"2.998×10^8", LocaleEnglish, 2.998e8},
+		{"6.626×10^−34", LocaleEnglish, 6.626e-34},
+		{"1,234,567", Locale{}, 1234567},
+		{"1.234.567,89", Locale{}, 1234567.89},
+		{"1,234.5", Locale{}, 1234.5},
+		{"1.609", Locale{}, 1.609},
+		{"1,234", Locale{}, 1234},
+		{"0,5", Locale{}, 0.5},
+		{"1 234 567", Locale{}, 1234567},
+	}
+	for _, test := range tests {
+		n, err := ParseNumber(test.s, test.locale)
+		assert.NoError(t, err, test.s)
+		assert.InEpsilon(t, test.expected, n, 1e-12, test.s)
+	}
+
+	for _, s := range []string{"", "abc", "1,23,4", "1.2.3", ",5", "12e5"} {
+		_, err := ParseNumber(s, LocaleGerman)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// A Recognizer extracts text from an image (OCR). Occasionally a subpod's
+// answer exists only as an image; when a Client has a Recognizer, it can
+// fill in the subpod's plaintext from the image, so text-only channels can
+// still answer. See Client.Recognize.
+type Recognizer interface {
+	// Recognize returns the text in the image, given the image file's
+	// contents (usually a GIF).
+	Recognize(image []byte) (string, error)
+}
+
+// The RecognizerFunc type is an adapter to allow the use of ordinary
+// functions as Recognizers.
+type RecognizerFunc func(image []byte) (string, error)
+
+// Recognize calls f(image).
+func (f RecognizerFunc) Recognize(image []byte) (string, error) {
+	return f(image)
+}
+
+// NopRecognizer is a Recognizer that never finds any text. It's the default
+// when a Client has no Recognizer.
+var NopRecognizer Recognizer = RecognizerFunc(func([]byte) (string, error) {
+	return "", nil
+})
+
+// Recognize fills in the plaintext of each of the result's subpods that has
+// an image but no plaintext, by downloading the image and running it through
+// the client's Recognizer. It does nothing if the client has no Recognizer.
+func (c Client) Recognize(ctx context.Context, r *Result) error {
+	if c.Recognizer == nil {
+		return nil
+	}
+	for i := range r.Pods {
+		for j := range r.Pods[i].Subpods {
+			subpod := &r.Pods[i].Subpods[j]
+			if subpod.Plaintext != "" || subpod.Image == nil || !safeURL(subpod.Image.URL) {
+				continue
+			}
+			data, err := c.fetch(ctx, subpod.Image.URL, nil)
+			if err != nil {
+				return err
+			}
+			if subpod.Plaintext, err = c.Recognizer.Recognize(data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fetch returns the contents of a secondary resource, like a pod image or an
+// async or recalculated pod, following the client's redirect policy. If
+// onResponse isn't nil, the response status and body are reported to it.
+//
+// Secondary URLs come from responses and may point anywhere the policy
+// allows, so the request carries only the User-Agent and Accept-Encoding
+// headers: the client's Header and Interceptors, which may hold credentials
+// meant for the API endpoint or a proxy, are left out.
+func (c Client) fetch(ctx context.Context, rawurl string, onResponse func(int, []byte)) ([]byte, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if !c.Redirects.Allowed(u) {
+		return nil, errors.New("api: fetch from disallowed host " + u.Host)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawurl, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := *c.httpClient()
+	client.CheckRedirect = c.Redirects.CheckRedirect
+	c.HTTPClient = &client
+	c.Header, c.Interceptors = nil, nil
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if onResponse != nil {
+		onResponse(resp.StatusCode, data)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("api: fetching %s: %s", rawurl, resp.Status)
+	}
+	return data, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Recognize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("GIF:" + r.URL.Path))
+	}))
+	defer server.Close()
+
+	result := Result{Pods: []Pod{
+		{Subpods: []Subpod{
+			{Plaintext: "x = 0", Image: &Image{URL: server.URL + "/a"}},
+			{Image: &Image{URL: server.URL + "/b"}},
+			{},
+		}},
+	}}
+	c := Client{Recognizer: RecognizerFunc(func(image []byte) (string, error) {
+		return "text from " + string(image), nil
+	})}
+	assert.NoError(t, c.Recognize(context.Background(), &result))
+	assert.Equal(t, "x = 0", result.Pods[0].Subpods[0].Plaintext)
+	assert.Equal(t, "text from GIF:/b", result.Pods[0].Subpods[1].Plaintext)
+	assert.Equal(t, "", result.Pods[0].Subpods[2].Plaintext)
+
+	result = Result{Pods: []Pod{{Subpods: []Subpod{{Image: &Image{URL: server.URL + "/missing"}}}}}}
+	assert.Error(t, c.Recognize(context.Background(), &result))
+
+	result = Result{Pods: []Pod{{Subpods: []Subpod{{Image: &Image{URL: server.URL + "/c"}}}}}}
+	c.Recognizer = RecognizerFunc(func([]byte) (string, error) { return "", errors.New("unreadable") })
+	assert.EqualError(t, c.Recognize(context.Background(), &result), "unreadable")
+
+	assert.NoError(t, Client{}.Recognize(context.Background(), &result))
+	assert.NoError(t, Client{Recognizer: NopRecognizer}.Recognize(context.Background(), &result))
+	assert.Equal(t, "", result.Pods[0].Subpods[0].Plaintext)
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// A QueryOption overrides part of a client's configuration for a single
+// query. See Client.QueryWithOptions.
+type QueryOption func(*Client)
+
+// WithFormats makes the query request the given formats instead of the
+// client's.
+func WithFormats(formats ...Format) QueryOption {
+	return func(c *Client) {
+		c.Formats = formats
+	}
+}
+
+// WithPodIDs restricts the query to the pods with the given IDs, instead of
+// the client's IncludePodIDs.
+func WithPodIDs(ids ...string) QueryOption {
+	return func(c *Client) {
+		c.IncludePodIDs = ids
+	}
+}
+
+// WithoutPodIDs leaves the pods with the given IDs out of the query, instead
+// of the client's ExcludePodIDs.
+func WithoutPodIDs(ids ...string) QueryOption {
+	return func(c *Client) {
+		c.ExcludePodIDs = ids
+	}
+}
+
+// WithUnits makes the query use the given unit system instead of the
+// client's.
+func WithUnits(units UnitSystem) QueryOption {
+	return func(c *Client) {
+		c.Units = units
+	}
+}
+
+// WithReinterpret makes Wolfram Alpha reinterpret queries it can't
+// understand, or not, or apply its default, instead of following the
+// client's Reinterpret.
+func WithReinterpret(t Toggle) QueryOption {
+	return func(c *Client) {
+		c.Reinterpret = t
+	}
+}
+
+// WithIgnoreCase makes Wolfram Alpha ignore case in the input, or not,
+// instead of following the client's IgnoreCase.
+func WithIgnoreCase(ignore bool) QueryOption {
+	return func(c *Client) {
+		c.IgnoreCase = ignore
+	}
+}
+
+// WithTranslation makes Wolfram Alpha translate non-English input, or not,
+// instead of following the client's Translation.
+func WithTranslation(translate bool) QueryOption {
+	return func(c *Client) {
+		c.Translation = translate
+	}
+}
+
+// WithCurrency makes the query give monetary values in the given currency
+// instead of the client's Currency.
+func WithCurrency(code CurrencyCode) QueryOption {
+	return func(c *Client) {
+		c.Currency = code
+	}
+}
+
+// WithLocation makes the query use the given location, like "Boston, MA",
+// instead of the client's IPAddress, LatLong, or Location.
+func WithLocation(location string) QueryOption {
+	return func(c *Client) {
+		c.IPAddress, c.LatLong, c.Location = "", nil, location
+	}
+}
+
+// WithTimeout makes the query time out after d instead of the client's
+// Timeout. A timeout set on the context with WithQueryTimeout still takes
+// precedence.
+func WithTimeout(d time.Duration) QueryOption {
+	return func(c *Client) {
+		c.Timeout = d
+	}
+}
+
+// WithScanTimeout makes Wolfram Alpha stop scanning the input after d,
+// instead of after the client's ScanTimeout.
+func WithScanTimeout(d time.Duration) QueryOption {
+	return func(c *Client) {
+		c.ScanTimeout = d
+	}
+}
+
+// WithPodTimeout makes Wolfram Alpha stop computing each pod after d,
+// instead of after the client's PodTimeout.
+func WithPodTimeout(d time.Duration) QueryOption {
+	return func(c *Client) {
+		c.PodTimeout = d
+	}
+}
+
+// WithFormatTimeout makes Wolfram Alpha stop formatting pods after d, instead
+// of after the client's FormatTimeout.
+func WithFormatTimeout(d time.Duration) QueryOption {
+	return func(c *Client) {
+		c.FormatTimeout = d
+	}
+}
+
+// WithParseTimeout makes Wolfram Alpha stop parsing the input after d,
+// instead of after the client's ParseTimeout.
+func WithParseTimeout(d time.Duration) QueryOption {
+	return func(c *Client) {
+		c.ParseTimeout = d
+	}
+}
+
+// WithAssumptions makes the query use the given assumption inputs (see
+// AssumptionValue.Input) in addition to any in the client's Extra.
+func WithAssumptions(inputs ...string) QueryOption {
+	return func(c *Client) {
+		extra := make(url.Values, len(c.Extra)+1)
+		for key, values := range c.Extra {
+			extra[key] = values
+		}
+		extra["assumption"] = append(c.Extra["assumption"][:0:0], c.Extra["assumption"]...)
+		extra["assumption"] = append(extra["assumption"], inputs...)
+		c.Extra = extra
+	}
+}
+
+// QueryWithOptions is like Query, but with the options applied to a copy of
+// the client, leaving the client itself untouched. It is equivalent to
+// QueryWithOptionsContext with a background context.
+func (c Client) QueryWithOptions(input string, opts ...QueryOption) (Result, error) {
+	return c.QueryWithOptionsContext(context.Background(), input, opts...)
+}
+
+// QueryWithOptionsContext is like QueryContext, but with the options applied
+// to a copy of the client, leaving the client itself untouched. Options
+// attached to ctx with WithContextOptions still take precedence.
+func (c Client) QueryWithOptionsContext(ctx context.Context, input string, opts ...QueryOption) (Result, error) {
+	return c.queryWithOptions(ctx, input, opts)
+}
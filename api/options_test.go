@@ -0,0 +1,119 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestClient_QueryWithOptions(t *testing.T) {
+	params := serve(t, resultXML)
+	c := Client{AppID: "XXXX", Formats: []Format{ImageF}, IPAddress: "192.0.2.1"}
+	_, err := c.QueryWithOptions(
+		"pi",
+		WithFormats(PlaintextFormat, MathMLFormat),
+		WithUnits(Metric),
+		WithLocation("Madrid"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"appid":    {"XXXX"},
+		"input":    {"pi"},
+		"format":   {"plaintext,mathml"},
+		"location": {"Madrid"},
+		"units":    {"metric"},
+	}, *params)
+	assert.Equal(t, Client{AppID: "XXXX", Formats: []Format{ImageF}, IPAddress: "192.0.2.1"}, c)
+}
+
+func TestWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+
+	c := Client{Endpoint: server.URL}
+	_, err := c.QueryWithOptions("pi", WithTimeout(10*time.Millisecond))
+	assert.Equal(t, 10*time.Millisecond, err.(*TimeoutError).Timeout)
+	_, err = c.QueryWithOptions("pi")
+	assert.NoError(t, err)
+}
+
+func TestWithPodIDs(t *testing.T) {
+	params := serve(t, resultXML)
+	c := Client{IncludePodIDs: []string{"Input"}, ExcludePodIDs: []string{"Plot"}}
+	_, err := c.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Input"}, (*params)["includepodid"])
+	assert.Equal(t, []string{"Plot"}, (*params)["excludepodid"])
+
+	_, err = c.QueryWithOptions("pi", WithPodIDs("Result", "DecimalApproximation"), WithoutPodIDs())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Result", "DecimalApproximation"}, (*params)["includepodid"])
+	assert.NotContains(t, *params, "excludepodid")
+}
+
+func TestWithIgnoreCase(t *testing.T) {
+	params := serve(t, resultXML)
+	_, err := Client{}.QueryWithOptions("WHO", WithIgnoreCase(true))
+	assert.NoError(t, err)
+	assert.Equal(t, "true", params.Get("ignorecase"))
+
+	_, err = Client{IgnoreCase: true}.QueryWithOptions("WHO", WithIgnoreCase(false))
+	assert.NoError(t, err)
+	assert.NotContains(t, *params, "ignorecase")
+}
+
+func TestWithTranslation(t *testing.T) {
+	params := serve(t, `<queryresult success="true">
+	                      <warnings count="1">
+	                        <translation phrase="wie hoch ist der Eiffelturm" trans="how high is the Eiffel tower" lang="German" text="Translating from German to &quot;how high is the Eiffel tower&quot;"/>
+	                      </warnings>
+	                    </queryresult>`)
+	r, err := Client{}.QueryWithOptions("wie hoch ist der Eiffelturm", WithTranslation(true))
+	assert.NoError(t, err)
+	assert.Equal(t, "true", params.Get("translation"))
+	assert.Equal(t, &Warning{
+		Type:        "translation",
+		Text:        `Translating from German to "how high is the Eiffel tower"`,
+		Phrase:      "wie hoch ist der Eiffelturm",
+		Translation: "how high is the Eiffel tower",
+		Language:    "German",
+	}, r.Translated())
+
+	_, err = Client{Translation: true}.QueryWithOptions("pi", WithTranslation(false))
+	assert.NoError(t, err)
+	assert.NotContains(t, *params, "translation")
+}
+
+func TestWithCurrency(t *testing.T) {
+	params := serve(t, resultXML)
+	c := Client{Currency: "USD", CountryCode: "CA"}
+	_, err := c.QueryWithOptions("price of gold", WithCurrency("EUR"))
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", params.Get("currency"))
+	assert.Equal(t, "CA", params.Get("countrycode"))
+
+	_, err = c.QueryWithOptions("price of gold", WithCurrency("euro"))
+	assert.IsType(t, &ConfigError{}, err)
+}
+
+func TestWithReinterpret(t *testing.T) {
+	params := serve(t, resultXML)
+	c := Client{Reinterpret: ToggleOn}
+	_, err := c.Query("kitty danger")
+	assert.NoError(t, err)
+	assert.Equal(t, "true", params.Get("reinterpret"))
+
+	_, err = c.QueryWithOptions("kitty danger", WithReinterpret(ToggleOff))
+	assert.NoError(t, err)
+	assert.Equal(t, "false", params.Get("reinterpret"))
+
+	_, err = c.QueryWithOptions("kitty danger", WithReinterpret(ToggleDefault))
+	assert.NoError(t, err)
+	assert.NotContains(t, *params, "reinterpret")
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+var (
+	// A trailing parenthetical spelling out a unit, like " (kilometers)"
+	unitNamePattern = regexp.MustCompile(`\s+\([A-Za-z][A-Za-z \-]*\)$`)
+
+	// A number followed by a unit, like "1.609 km" or "3.3×10^8 m/s"
+	quantityPattern = regexp.MustCompile(`^[-+−~]?[0-9][0-9.,]*(?:\s*×\s*10\^-?[0-9]+)?\s*([^0-9\s].*)$`)
+
+	// The units CleanPlaintext can convert between, by name
+	plaintextUnits = unitTable([]struct {
+		names string
+		unit  unit
+	}{
+		{"mm millimeters", unit{Metric, "length", 1e-3, 0}},
+		{"cm centimeters", unit{Metric, "length", 1e-2, 0}},
+		{"m meter meters", unit{Metric, "length", 1, 0}},
+		{"km kilometer kilometers", unit{Metric, "length", 1e3, 0}},
+		{"in inch inches", unit{Imperial, "length", 0.0254, 0}},
+		{"ft foot feet", unit{Imperial, "length", 0.3048, 0}},
+		{"yd yard yards", unit{Imperial, "length", 0.9144, 0}},
+		{"mi mile miles", unit{Imperial, "length", 1609.344, 0}},
+		{"mg milligrams", unit{Metric, "mass", 1e-6, 0}},
+		{"g gram grams", unit{Metric, "mass", 1e-3, 0}},
+		{"kg kilogram kilograms", unit{Metric, "mass", 1, 0}},
+		{"t tonnes", unit{Metric, "mass", 1e3, 0}},
+		{"oz ounce ounces", unit{Imperial, "mass", 0.028349523125, 0}},
+		{"lb lbs pound pounds", unit{Imperial, "mass", 0.45359237, 0}},
+		{"ml mL milliliters", unit{Metric, "volume", 1e-3, 0}},
+		{"l L liter liters", unit{Metric, "volume", 1, 0}},
+		{"pt pints", unit{Imperial, "volume", 0.473176473, 0}},
+		{"qt quarts", unit{Imperial, "volume", 0.946352946, 0}},
+		{"gal gallon gallons", unit{Imperial, "volume", 3.785411784, 0}},
+		{"ha hectares", unit{Metric, "area", 1e4, 0}},
+		{"acres", unit{Imperial, "area", 4046.8564224, 0}},
+		{"Pa", unit{Metric, "pressure", 1, 0}},
+		{"kPa", unit{Metric, "pressure", 1e3, 0}},
+		{"psi", unit{Imperial, "pressure", 6894.757293168, 0}},
+		{"m/s", unit{Metric, "speed", 1, 0}},
+		{"km/h", unit{Metric, "speed", 1 / 3.6, 0}},
+		{"ft/s", unit{Imperial, "speed", 0.3048, 0}},
+		{"mph", unit{Imperial, "speed", 0.44704, 0}},
+		{"°C", unit{Metric, "temperature", 1, 0}},
+		{"°F", unit{Imperial, "temperature", 5.0 / 9, -32}},
+	})
+)
+
+// CleanPlaintext returns a human-friendly version of Wolfram Alpha plaintext
+// for display. It drops parenthesized unit names ("1.609 km (kilometers)"
+// becomes "1.609 km"), and when the plaintext gives one quantity in both
+// metric and imperial units, as two values that convert to each other, one
+// per line or separated by " | ", it keeps only the one in the preferred unit
+// system (or the first one if units is Location). Plaintext listing anything
+// else, like different values or units it doesn't recognize, is returned
+// unchanged.
+func CleanPlaintext(s string, units UnitSystem) string {
+	sep := "\n"
+	if !strings.Contains(s, sep) {
+		sep = " | "
+	}
+	segments := strings.Split(s, sep)
+	for i, segment := range segments {
+		segments[i] = unitNamePattern.ReplaceAllString(strings.TrimSpace(segment), "")
+	}
+	if len(segments) == 1 {
+		return segments[0]
+	}
+	if len(segments) != 2 {
+		return s
+	}
+
+	a, okA := parseQuantity(segments[0])
+	b, okB := parseQuantity(segments[1])
+	if !okA || !okB || a.unit.system == b.unit.system || a.unit.dimension != b.unit.dimension || !a.equals(b) {
+		return s
+	}
+	if b.unit.system == units {
+		return segments[1]
+	}
+	return segments[0]
+}
+
+// CleanPlaintext returns a human-friendly version of Wolfram Alpha plaintext
+// for display, preferring the client's unit system. See CleanPlaintext.
+func (c Client) CleanPlaintext(s string) string {
+	return CleanPlaintext(s, c.Units)
+}
+
+// A unit is a unit of measurement CleanPlaintext recognizes.
+type unit struct {
+	// The unit system the unit belongs to
+	system UnitSystem
+
+	// What the unit measures, like "length"
+	dimension string
+
+	// The factor and offset converting a value in the unit to the base unit
+	// of its dimension: base = (value + offset) * factor
+	factor, offset float64
+}
+
+// unitTable returns a table of units by name from a list of units with their
+// space-separated names.
+func unitTable(entries []struct {
+	names string
+	unit  unit
+}) map[string]unit {
+	table := make(map[string]unit)
+	for _, entry := range entries {
+		for _, name := range strings.Fields(entry.names) {
+			table[name] = entry.unit
+		}
+	}
+	return table
+}
+
+// A quantity is a value in a recognized unit, like "1.609 km".
+type quantity struct {
+	// The value in the base unit of its dimension
+	value float64
+
+	// Half of the value's last displayed decimal place, in the base unit
+	precision float64
+
+	// The unit the value was given in
+	unit unit
+}
+
+// parseQuantity parses a quantity like "1.609 km". The boolean is false if
+// the string is not a quantity or its unit is unrecognized.
+func parseQuantity(s string) (quantity, bool) {
+	m := quantityPattern.FindStringSubmatch(s)
+	if m == nil {
+		return quantity{}, false
+	}
+	u, ok := plaintextUnits[strings.TrimSpace(m[1])]
+	if !ok {
+		return quantity{}, false
+	}
+	n := answerNumberPattern.FindStringSubmatch(s)
+	if n == nil {
+		return quantity{}, false
+	}
+	value, err := ParseNumber(n[0], LocaleEnglish)
+	if err != nil {
+		return quantity{}, false
+	}
+	return quantity{
+		value:     (value + u.offset) * u.factor,
+		precision: lastPlace(n) / 2 * u.factor,
+		unit:      u,
+	}, true
+}
+
+// equals reports whether two quantities of the same dimension are equal,
+// allowing for the rounding of their displayed values and a 1% margin for
+// conversions Wolfram Alpha rounds more coarsely.
+func (q quantity) equals(other quantity) bool {
+	tolerance := math.Max(q.precision+other.precision, 0.01*math.Max(math.Abs(q.value), math.Abs(other.value)))
+	return math.Abs(q.value-other.value) <= tolerance
+}
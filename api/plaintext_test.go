@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCleanPlaintext(t *testing.T) {
+	assert.Equal(t, "1.609 km", CleanPlaintext("1.609 km (kilometers)", Metric))
+	assert.Equal(t, "1.609 km", CleanPlaintext("1 mile (miles) | 1.609 km (kilometers)", Metric))
+	assert.Equal(t, "1 mile", CleanPlaintext("1 mile (miles) | 1.609 km (kilometers)", Imperial))
+	assert.Equal(t, "68 °F", CleanPlaintext("20 °C (degrees Celsius)\n68 °F (degrees Fahrenheit)", Imperial))
+	assert.Equal(t, "88 km/h", CleanPlaintext("55 mph (miles per hour)\n88 km/h (kilometers per hour)", Metric))
+	assert.Equal(t, "1 mile", CleanPlaintext("1.609 km | 1 mile", Imperial))
+	assert.Equal(t, "3 widgets | 4 gadgets", CleanPlaintext("3 widgets | 4 gadgets", Metric))
+	assert.Equal(t, "5 kg | 3 m", CleanPlaintext("5 kg | 3 m", Imperial))
+	assert.Equal(t, "1 mile (miles) | 3 widgets", CleanPlaintext("1 mile (miles) | 3 widgets", Imperial))
+	assert.Equal(t, "20 °C", CleanPlaintext("20 °C | 68 °F", Location))
+	assert.Equal(t, "10 miles | 2 km", CleanPlaintext("10 miles | 2 km", Metric))
+	assert.Equal(t, "1 mile | 1.609 km | 5 ft", CleanPlaintext("1 mile | 1.609 km | 5 ft", Metric))
+	assert.Equal(t, "2 kg | 2 mi", CleanPlaintext("2 kg | 2 mi", Metric))
+	assert.Equal(
+		t,
+		"population | 8.4 million people (2010 estimate)",
+		CleanPlaintext("population | 8.4 million people (2010 estimate)", Metric),
+	)
+}
+
+func TestClient_CleanPlaintext(t *testing.T) {
+	assert.Equal(t, "1.609 km", Client{Units: Metric}.CleanPlaintext("1 mi | 1.609 km"))
+}
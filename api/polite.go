@@ -0,0 +1,44 @@
+package api
+
+import (
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Polite returns an Interceptor that spaces out the requests it sees, so
+// that each starts at least delay (plus a random jitter of up to jitter)
+// after the previous one. It's meant for batch jobs running many queries,
+// to be a good API citizen and stay clear of burst protection. Requests wait
+// in turn, and stop waiting if their context is canceled. Install it with
+// Client.Use; copies of the client share the spacing.
+func Polite(delay, jitter time.Duration) Interceptor {
+	var mu sync.Mutex
+	var next time.Time
+	return func(req *http.Request) error {
+		mu.Lock()
+		now := time.Now()
+		start := next
+		if start.Before(now) {
+			start = now
+		}
+		gap := delay
+		if jitter > 0 {
+			gap += time.Duration(rand.Int63n(int64(jitter)))
+		}
+		next = start.Add(gap)
+		mu.Unlock()
+
+		if wait := start.Sub(now); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-req.Context().Done():
+				return req.Context().Err()
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPolite(t *testing.T) {
+	polite := Polite(30*time.Millisecond, 10*time.Millisecond)
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+
+	var starts []time.Time
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, polite(req))
+		starts = append(starts, time.Now())
+	}
+	for i := 1; i < len(starts); i++ {
+		assert.GreaterOrEqual(t, starts[i].Sub(starts[i-1]), 25*time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := polite(req.WithContext(ctx))
+	assert.True(t, errors.Is(err, context.Canceled))
+}
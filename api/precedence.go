@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+)
+
+// A query's configuration can come from several places. Where they disagree,
+// the most specific wins, in this order:
+//
+//  1. options attached to the context with WithContextOptions (or
+//     WithQueryTimeout)
+//  2. per-query options passed to QueryWithOptions
+//  3. the client's own fields
+//  4. environment variables, for client fields left unset (zero):
+//     WOLFRAM_APP_ID for AppID (unless there is an AppIDs pool),
+//     WOLFRAM_ENDPOINT for Endpoint, and WOLFRAM_TIMEOUT for Timeout (a
+//     duration like "10s", or seconds)
+//  5. the package defaults
+//
+// Every request the client makes resolves its configuration this way, with
+// resolve.
+
+// resolve returns the client's configuration for a request sent with ctx and
+// the per-query options, following the precedence rules above.
+func (c Client) resolve(ctx context.Context, opts []QueryOption) (Client, error) {
+	if err := c.applyEnv(); err != nil {
+		return c, err
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if ctx != nil {
+		ctxOpts, _ := ctx.Value(optionsKey).([]QueryOption)
+		for _, opt := range ctxOpts {
+			opt(&c)
+		}
+	}
+	return c, nil
+}
+
+// applyEnv fills the client fields that are unset from the environment.
+func (c *Client) applyEnv() error {
+	if id := os.Getenv("WOLFRAM_APP_ID"); c.AppID == "" && c.AppIDs == nil && id != "" {
+		c.AppID = id
+	}
+	if endpoint := os.Getenv("WOLFRAM_ENDPOINT"); c.Endpoint == "" && endpoint != "" {
+		c.Endpoint = endpoint
+	}
+	if timeout := os.Getenv("WOLFRAM_TIMEOUT"); c.Timeout == 0 && timeout != "" {
+		d, err := parseEnvDuration(timeout)
+		if err != nil {
+			return &ConfigError{Field: "Timeout", Message: "WOLFRAM_TIMEOUT " + strconv.Quote(timeout) + " is not a duration"}
+		}
+		c.Timeout = d
+	}
+	return nil
+}
+
+// parseEnvDuration parses a duration like "10s", or a plain number of
+// seconds, as the API's own timeout parameters take.
+func parseEnvDuration(s string) (time.Duration, error) {
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(secs * float64(time.Second)), nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestClient_resolve(t *testing.T) {
+	t.Setenv("WOLFRAM_APP_ID", "ENV")
+	t.Setenv("WOLFRAM_ENDPOINT", "https://proxy.example.com/query")
+	t.Setenv("WOLFRAM_TIMEOUT", "1.5")
+
+	c, err := Client{}.resolve(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "ENV", c.AppID)
+	assert.Equal(t, "https://proxy.example.com/query", c.Endpoint)
+	assert.Equal(t, 1500*time.Millisecond, c.Timeout)
+
+	client := Client{AppID: "CLIENT", Timeout: time.Second, Units: Metric, Location: "Madrid"}
+	c, err = client.resolve(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "CLIENT", c.AppID)
+	assert.Equal(t, time.Second, c.Timeout)
+
+	c, err = client.resolve(context.Background(), []QueryOption{WithTimeout(2 * time.Second), WithUnits(Imperial)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, c.Timeout)
+	assert.Equal(t, Imperial, c.Units)
+	assert.Equal(t, "Madrid", c.Location)
+
+	ctx := WithContextOptions(context.Background(), WithTimeout(3*time.Second), WithLocation("Boston, MA"))
+	ctx = WithQueryTimeout(ctx, 4*time.Second)
+	c, err = client.resolve(ctx, []QueryOption{WithTimeout(2 * time.Second), WithUnits(Imperial)})
+	assert.NoError(t, err)
+	assert.Equal(t, 4*time.Second, c.Timeout)
+	assert.Equal(t, Imperial, c.Units)
+	assert.Equal(t, "Boston, MA", c.Location)
+
+	t.Setenv("WOLFRAM_TIMEOUT", "soon")
+	_, err = Client{}.resolve(context.Background(), nil)
+	assert.Equal(t, &ConfigError{Field: "Timeout", Message: `WOLFRAM_TIMEOUT "soon" is not a duration`}, err)
+	_, err = Client{Timeout: time.Second}.resolve(context.Background(), nil)
+	assert.NoError(t, err)
+}
+
+func TestWithContextOptions(t *testing.T) {
+	params := serve(t, resultXML)
+	ctx := WithContextOptions(context.Background(), WithUnits(Metric))
+	_, err := Client{}.QueryWithOptionsContext(ctx, "10 feet in meters", WithUnits(Imperial))
+	assert.NoError(t, err)
+	assert.Equal(t, "metric", params.Get("units"))
+
+	_, err = Client{}.QueryContext(WithContextOptions(ctx, WithUnits(Imperial)), "10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, "nonmetric", params.Get("units"))
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A RankedItem is one entry in the answer to a superlative query, like
+// "largest countries by area".
+type RankedItem struct {
+	// The item's rank, starting at 1
+	Rank int
+
+	// The item's name, like "Russia"
+	Name string
+
+	// The quantity the items are ranked by, like 6.602 million mi^2
+	Quantity Quantity
+}
+
+// A RankedList is the answer to a superlative query, in rank order.
+type RankedList []RankedItem
+
+// RankedList decodes the subpod's plaintext as a ranked table, like
+//
+//	1 | Russia | 6.602 million mi^2 (square miles)
+//	2 | Canada | 3.855 million mi^2 (square miles)
+//
+// The rank column is optional (rows are ranked in order without it), the name
+// is the first other column, and the quantity is the last column. A header
+// row, whose last column isn't a quantity, is skipped. See Subpod.Table and
+// ParseQuantity.
+func (s Subpod) RankedList() (RankedList, error) {
+	rows, err := s.Table()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ParseQuantity(rows[0][len(rows[0])-1]); err != nil {
+		rows = rows[1:]
+	}
+
+	list := make(RankedList, 0, len(rows))
+	for i, row := range rows {
+		if len(row) < 2 {
+			return nil, ErrNotTable
+		}
+		item := RankedItem{Rank: i + 1, Name: row[0]}
+		if rank, err := strconv.Atoi(strings.Trim(row[0], "#.")); err == nil && len(row) > 2 {
+			item.Rank, item.Name = rank, row[1]
+		}
+		if item.Quantity, err = ParseQuantity(row[len(row)-1]); err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSubpod_RankedList(t *testing.T) {
+	list, err := Subpod{Plaintext: "rank | country | total area\n" +
+		"1 | Russia | 6.602 million mi^2 (square miles)\n" +
+		"2 | Canada | 3.855 million mi^2 (square miles)\n" +
+		"3 | United States | 3.794 million mi^2 (square miles)"}.RankedList()
+	assert.NoError(t, err)
+	assert.Equal(t, RankedList{
+		{Rank: 1, Name: "Russia", Quantity: Quantity{Value: 6.602e6, Unit: "mi^2"}},
+		{Rank: 2, Name: "Canada", Quantity: Quantity{Value: 3.855e6, Unit: "mi^2"}},
+		{Rank: 3, Name: "United States", Quantity: Quantity{Value: 3.794e6, Unit: "mi^2"}},
+	}, list)
+
+	list, err = Subpod{Plaintext: "Jupiter | 69911 km\nSaturn | 58232 km"}.RankedList()
+	assert.NoError(t, err)
+	assert.Equal(t, RankedList{
+		{Rank: 1, Name: "Jupiter", Quantity: Quantity{Value: 69911, Unit: "km"}},
+		{Rank: 2, Name: "Saturn", Quantity: Quantity{Value: 58232, Unit: "km"}},
+	}, list)
+
+	_, err = Subpod{Plaintext: "Jupiter"}.RankedList()
+	assert.Equal(t, ErrNotTable, err)
+	_, err = Subpod{Plaintext: "Jupiter | big\nSaturn | 58232 km"}.RankedList()
+	assert.NoError(t, err)
+	_, err = Subpod{Plaintext: "Jupiter | 69911 km\nSaturn | big"}.RankedList()
+	assert.EqualError(t, err, "api: invalid quantity: big")
+}
+
+func TestParseQuantity(t *testing.T) {
+	for s, q := range map[string]Quantity{
+		"3.048 meters":                      {Value: 3.048, Unit: "meters"},
+		"6.602 million mi^2 (square miles)": {Value: 6.602e6, Unit: "mi^2"},
+		"8.4 million people":                {Value: 8.4e6, Unit: "people"},
+		"1,234 km":                          {Value: 1234, Unit: "km"},
+		"42":                                {Value: 42},
+		"~3 million":                        {Value: 3e6},
+		"3.3×10^8 m/s (meters per second)":  {Value: 3.3e8, Unit: "m/s"},
+	} {
+		got, err := ParseQuantity(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, q.Unit, got.Unit, s)
+		assert.InEpsilon(t, q.Value, got.Value, 1e-9, s)
+	}
+	_, err := ParseQuantity("meters")
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RecalculateValidity is how long a recalculate URL is taken to stay usable
+// after it was issued. Wolfram Alpha only keeps the state behind the URL for a
+// short while, and answers stale URLs with empty results rather than errors.
+var RecalculateValidity = 10 * time.Minute
+
+// ErrRecalculateExpired occurs when recalculating a result whose recalculate
+// URL is older than RecalculateValidity.
+var ErrRecalculateExpired = errors.New("api: recalculate URL expired")
+
+// A RecalculateHandle is a parsed recalculate URL (see Result.Recalculate).
+type RecalculateHandle struct {
+	// The URL to fetch
+	URL *url.URL
+
+	// The recalculation's identifier, from the URL's "id" parameter
+	ID string
+
+	// The time the URL was issued, or the zero time if unknown
+	Issued time.Time
+}
+
+// ParseRecalculate parses a recalculate URL issued at the given time.
+func ParseRecalculate(rawURL string, issued time.Time) (RecalculateHandle, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return RecalculateHandle{}, err
+	}
+	if !u.IsAbs() {
+		return RecalculateHandle{}, errors.New("api: recalculate URL is not absolute")
+	}
+	return RecalculateHandle{URL: u, ID: u.Query().Get("id"), Issued: issued}, nil
+}
+
+// RecalculateHandle returns the handle for the result's Recalculate URL,
+// issued when the result was received. It returns false if the result has no
+// Recalculate URL or it can't be parsed.
+func (r Result) RecalculateHandle() (RecalculateHandle, bool) {
+	if r.Recalculate == "" {
+		return RecalculateHandle{}, false
+	}
+	h, err := ParseRecalculate(r.Recalculate, r.Received)
+	return h, err == nil
+}
+
+// Expired reports whether the handle was issued more than
+// RecalculateValidity ago. Handles issued at an unknown time never expire.
+func (h RecalculateHandle) Expired() bool {
+	return !h.Issued.IsZero() && time.Since(h.Issued) > RecalculateValidity
+}
+
+// Progress reports how complete a result is while QueryContext recalculates
+// pods that timed out, before it returns the merged result. See
+// Client.RecalculateBudget.
+type Progress struct {
+	// The number of pods received so far
+	Received int
+
+	// The number of pods expected: those received plus those that timed out
+	Expected int
+
+	// The number of recalculate requests made so far
+	Recalculations int
+}
+
+// Done reports whether all of the expected pods have been received.
+func (p Progress) Done() bool {
+	return p.Received >= p.Expected
+}
+
+// progress returns the result's progress after n recalculations.
+func (r Result) progress(n int) Progress {
+	p := Progress{Received: len(r.Pods), Recalculations: n}
+	p.Expected = p.Received
+	for _, id := range strings.Split(r.TimedOut, ",") {
+		if strings.TrimSpace(id) != "" {
+			p.Expected++
+		}
+	}
+	return p
+}
+
+// complete recalculates the result until it has no recalculate URL or the
+// client's recalculate budget is spent, reporting progress to the client's
+// OnProgress callback along the way.
+func (c Client) complete(ctx context.Context, r *Result) error {
+	report := func(n int) {
+		if c.OnProgress != nil {
+			c.OnProgress(r.progress(n))
+		}
+	}
+	report(0)
+	for n := 1; n <= c.RecalculateBudget && r.Recalculate != ""; n++ {
+		if err := c.recalculate(ctx, r); err != nil {
+			return err
+		}
+		report(n)
+	}
+	return nil
+}
+
+// Recalculate fetches the pods that timed out in the result from its
+// Recalculate URL, runs them through the client's Filters, and merges them
+// into the result in positional order (see Result.Recalculate). It makes a
+// single request and does nothing if the result has no Recalculate URL; the
+// merged result may have a new one if some pods timed out again. It returns
+// ErrRecalculateExpired, without making a request, if the Recalculate URL has
+// expired. Queries can recalculate automatically; see RecalculateBudget.
+func (c Client) Recalculate(ctx context.Context, r *Result) error {
+	if r.Recalculate == "" {
+		return nil
+	}
+	return c.recalculate(ctx, r)
+}
+
+// recalculate fetches the result's recalculate URL and merges the new pods
+// into the result.
+func (c Client) recalculate(ctx context.Context, r *Result) error {
+	h, err := ParseRecalculate(r.Recalculate, r.Received)
+	if err != nil {
+		return err
+	}
+	if h.Expired() {
+		return ErrRecalculateExpired
+	}
+	data, err := c.fetch(ctx, h.URL.String(), c.OnResponse)
+	if err != nil {
+		return err
+	}
+	var more Result
+	if err := decode(data, Fingerprint(h.URL.Query()), &more); err != nil {
+		return err
+	}
+	if more.Error != nil {
+		return more.Error
+	}
+	more.Pods = c.filterPods(r, more.Pods)
+	r.merge(more)
+	r.Received = time.Now()
+	return nil
+}
+
+// merge adds the other result's pods to the result in positional order,
+// replacing pods with the same ID, and takes on the other result's timeout
+// information and recalculate URL.
+func (r *Result) merge(other Result) {
+	r.Pods = append(r.Pods, r.replacePods(other.Pods, nil)...)
+	sort.SliceStable(r.Pods, func(i, j int) bool {
+		return r.Pods[i].Position < r.Pods[j].Position
+	})
+	r.Sources = append(r.Sources, other.Sources...)
+	r.TimedOut = other.TimedOut
+	r.Recalculate = other.Recalculate
+}
+
+// replacePods replaces the result's pods with the replacements that have the
+// same IDs and for which ok (if not nil) returns true. Pods sharing an ID are
+// paired with replacements in order, so the second replacement with an ID
+// takes the place of the second pod with it. replacePods returns the
+// replacements left without a pod to replace.
+func (r *Result) replacePods(replacements []Pod, ok func(Pod) bool) []Pod {
+	replaced := make([]bool, len(r.Pods))
+	var unmatched []Pod
+	for _, pod := range replacements {
+		i := 0
+		for ; i < len(r.Pods); i++ {
+			if !replaced[i] && r.Pods[i].ID == pod.ID {
+				break
+			}
+		}
+		if i == len(r.Pods) {
+			unmatched = append(unmatched, pod)
+			continue
+		}
+		replaced[i] = true
+		if ok == nil || ok(pod) {
+			r.Pods[i] = pod
+		}
+	}
+	return unmatched
+}
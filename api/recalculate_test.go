@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResult_merge(t *testing.T) {
+	result := Result{
+		Pods:        []Pod{{ID: "Input", Position: 100}, {ID: "Result", Position: 200}, {ID: "Map", Position: 500}},
+		TimedOut:    "Data,Map",
+		Recalculate: "http://api.wolframalpha.com/v2/recalc.jsp?id=1",
+	}
+	result.merge(Result{Pods: []Pod{{ID: "Data", Position: 300}, {ID: "Map", Position: 500, Title: "Map"}}})
+	assert.Equal(t, Result{
+		Pods: []Pod{
+			{ID: "Input", Position: 100},
+			{ID: "Result", Position: 200},
+			{ID: "Data", Position: 300},
+			{ID: "Map", Position: 500, Title: "Map"},
+		},
+	}, result)
+}
+
+func TestResult_replacePods(t *testing.T) {
+	result := Result{Pods: []Pod{
+		{ID: "Input"},
+		{ID: "Result:Data", Title: "first"},
+		{ID: "Result:Data", Title: "second"},
+	}}
+	unmatched := result.replacePods([]Pod{
+		{ID: "Result:Data", Title: "first (retried)"},
+		{ID: "Result:Data"},
+		{ID: "Map"},
+	}, func(pod Pod) bool { return pod.Title != "" })
+	assert.Equal(t, []Pod{{ID: "Map"}}, unmatched)
+	assert.Equal(t, []Pod{
+		{ID: "Input"},
+		{ID: "Result:Data", Title: "first (retried)"},
+		{ID: "Result:Data", Title: "second"},
+	}, result.Pods)
+}
+
+func TestResult_progress(t *testing.T) {
+	result := Result{Pods: []Pod{{ID: "Input"}, {ID: "Result"}}, TimedOut: "Data,Map"}
+	assert.Equal(t, Progress{Received: 2, Expected: 4, Recalculations: 1}, result.progress(1))
+	assert.False(t, result.progress(1).Done())
+	assert.True(t, Result{Pods: []Pod{{ID: "Input"}}}.progress(0).Done())
+}
+
+func TestClient_Query_recalculate(t *testing.T) {
+	var recalcURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("id") {
+		case "":
+			w.Write([]byte(`<queryresult success="true" timedout="Data,Map" recalculate="` + recalcURL + `?id=1">
+			                  <pod id="Input" position="100"/>
+			                  <pod id="Result" position="200"/>
+			                </queryresult>`))
+		case "1":
+			w.Write([]byte(`<queryresult success="true" timedout="Map" recalculate="` + recalcURL + `?id=2">
+			                  <pod id="Data" position="300"/>
+			                </queryresult>`))
+		case "2":
+			w.Write([]byte(`<queryresult success="true"><pod id="Map" position="400"/></queryresult>`))
+		}
+	}))
+	defer server.Close()
+	recalcURL = server.URL
+	orig := queryURL
+	queryURL = server.URL
+	defer func() { queryURL = orig }()
+
+	var progress []Progress
+	c := Client{RecalculateBudget: 5, OnProgress: func(p Progress) { progress = append(progress, p) }}
+	result, err := c.Query("pi")
+	assert.NoError(t, err)
+	assert.Len(t, result.Pods, 4)
+	assert.Equal(t, "", result.Recalculate)
+	assert.Equal(t, []Progress{
+		{Received: 2, Expected: 4, Recalculations: 0},
+		{Received: 3, Expected: 4, Recalculations: 1},
+		{Received: 4, Expected: 4, Recalculations: 2},
+	}, progress)
+
+	c.RecalculateBudget = 1
+	result, err = c.Query("pi")
+	assert.NoError(t, err)
+	assert.Len(t, result.Pods, 3)
+	assert.Equal(t, recalcURL+"?id=2", result.Recalculate)
+
+	result, err = Client{}.Query("pi")
+	assert.NoError(t, err)
+	assert.Len(t, result.Pods, 2)
+
+	assert.NoError(t, Client{}.Recalculate(context.Background(), &result))
+	assert.Len(t, result.Pods, 3)
+	assert.Equal(t, "Data", result.Pods[2].ID)
+	assert.NoError(t, Client{}.Recalculate(context.Background(), &result))
+	assert.Len(t, result.Pods, 4)
+	assert.Equal(t, "", result.Recalculate)
+	assert.NoError(t, Client{}.Recalculate(context.Background(), &result))
+	assert.Len(t, result.Pods, 4)
+}
+
+func TestParseRecalculate(t *testing.T) {
+	issued := time.Now()
+	h, err := ParseRecalculate("https://api.wolframalpha.com/v2/recalc.jsp?id=MSP123&s=7", issued)
+	assert.NoError(t, err)
+	assert.Equal(t, "MSP123", h.ID)
+	assert.Equal(t, "api.wolframalpha.com", h.URL.Host)
+	assert.False(t, h.Expired())
+
+	h.Issued = issued.Add(-RecalculateValidity - time.Second)
+	assert.True(t, h.Expired())
+	h.Issued = time.Time{}
+	assert.False(t, h.Expired())
+
+	_, err = ParseRecalculate("recalc.jsp?id=1", issued)
+	assert.Error(t, err)
+
+	_, ok := Result{}.RecalculateHandle()
+	assert.False(t, ok)
+	h, ok = Result{Recalculate: "https://example.com/recalc?id=2", Received: issued}.RecalculateHandle()
+	assert.True(t, ok)
+	assert.Equal(t, "2", h.ID)
+	assert.Equal(t, issued, h.Issued)
+}
+
+func TestClient_Recalculate_expired(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	r := Result{Recalculate: server.URL + "?id=1", Received: time.Now().Add(-time.Hour)}
+	err := Client{}.Recalculate(context.Background(), &r)
+	assert.Equal(t, ErrRecalculateExpired, err)
+	assert.Zero(t, requests)
+}
+
+func TestClient_Recalculate_filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<queryresult success="true"><pod id="Dosage" title="Dosage" scanner="Drug" position="200"/></queryresult>`))
+	}))
+	defer server.Close()
+
+	var statuses []int
+	c := Client{Filters: []Filter{MinorsFilter}, OnResponse: func(status int, body []byte) { statuses = append(statuses, status) }}
+	r := Result{Pods: []Pod{{ID: "Input", Position: 100}}, Recalculate: server.URL + "?id=1"}
+	assert.NoError(t, c.Recalculate(context.Background(), &r))
+	assert.Len(t, r.Pods, 1)
+	assert.Equal(t, []FilteredPod{{ID: "Dosage", Title: "Dosage", Reason: "scanner Drug"}}, r.Filtered)
+	assert.Equal(t, []int{http.StatusOK}, statuses)
+}
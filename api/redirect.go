@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxRedirects is the number of redirects followed when fetching
+// secondary resources if a RedirectPolicy doesn't say otherwise.
+const DefaultMaxRedirects = 10
+
+// A RedirectPolicy limits where the package goes when it fetches secondary
+// resources, like pod images. Image and recalculate URLs sometimes redirect,
+// and when results come from an untrusted cache, following their URLs blindly
+// invites SSRF-style surprises.
+type RedirectPolicy struct {
+	// The maximum number of redirects to follow. If zero, DefaultMaxRedirects
+	// are followed; if negative, none are.
+	MaxRedirects int
+
+	// The hosts that may be fetched from, either directly or by redirect. A
+	// host also allows its subdomains, so "wolframalpha.com" allows
+	// "www5b.wolframalpha.com". If empty, any host is allowed.
+	AllowedHosts []string
+}
+
+// Allowed reports whether the policy allows fetching from the URL's host.
+func (p RedirectPolicy) Allowed(u *url.URL) bool {
+	if len(p.AllowedHosts) == 0 {
+		return true
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range p.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRedirect enforces the policy on a redirect. It has the signature of
+// http.Client's CheckRedirect field.
+func (p RedirectPolicy) CheckRedirect(req *http.Request, via []*http.Request) error {
+	max := p.MaxRedirects
+	if max == 0 {
+		max = DefaultMaxRedirects
+	}
+	if len(via) > max {
+		return errors.New("api: stopped after " + strconv.Itoa(len(via)-1) + " redirects")
+	}
+	if !p.Allowed(req.URL) {
+		return errors.New("api: redirect to disallowed host " + req.URL.Host)
+	}
+	return nil
+}
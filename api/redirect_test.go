@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRedirectPolicy_Allowed(t *testing.T) {
+	u, _ := url.Parse("http://www5b.wolframalpha.com/Calculate/MSP/MSP1")
+	assert.True(t, RedirectPolicy{}.Allowed(u))
+	assert.True(t, RedirectPolicy{AllowedHosts: []string{"wolframalpha.com"}}.Allowed(u))
+	assert.True(t, RedirectPolicy{AllowedHosts: []string{"WWW5B.wolframalpha.com"}}.Allowed(u))
+	assert.False(t, RedirectPolicy{AllowedHosts: []string{"example.com"}}.Allowed(u))
+
+	u, _ = url.Parse("http://evilwolframalpha.com/")
+	assert.False(t, RedirectPolicy{AllowedHosts: []string{"wolframalpha.com"}}.Allowed(u))
+}
+
+func TestClient_fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// /hop/N redirects N more times before landing on /image.
+		if strings.HasPrefix(r.URL.Path, "/hop/") {
+			n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hop/"))
+			if n == 0 {
+				http.Redirect(w, r, "/image", http.StatusFound)
+			} else {
+				http.Redirect(w, r, "/hop/"+strconv.Itoa(n-1), http.StatusFound)
+			}
+			return
+		}
+		if r.URL.Path == "/away" {
+			http.Redirect(w, r, "http://example.com/", http.StatusFound)
+			return
+		}
+		w.Write([]byte("GIF"))
+	}))
+	defer server.Close()
+	host, _ := url.Parse(server.URL)
+
+	data, err := Client{}.fetch(context.Background(), server.URL+"/hop/2", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "GIF", string(data))
+
+	_, err = Client{Redirects: RedirectPolicy{MaxRedirects: 2}}.fetch(context.Background(), server.URL+"/hop/2", nil)
+	assert.EqualError(t, err, `Get "/image": api: stopped after 2 redirects`)
+
+	_, err = Client{Redirects: RedirectPolicy{MaxRedirects: -1}}.fetch(context.Background(), server.URL+"/hop/0", nil)
+	assert.Error(t, err)
+
+	policy := RedirectPolicy{AllowedHosts: []string{host.Hostname()}}
+	_, err = Client{Redirects: policy}.fetch(context.Background(), server.URL+"/away", nil)
+	assert.EqualError(t, err, `Get "http://example.com/": api: redirect to disallowed host example.com`)
+
+	_, err = Client{Redirects: RedirectPolicy{AllowedHosts: []string{"example.com"}}}.fetch(context.Background(), server.URL+"/image", nil)
+	assert.EqualError(t, err, "api: fetch from disallowed host "+host.Host)
+}
+
+func TestClient_fetch_plain(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte("GIF"))
+	}))
+	defer server.Close()
+
+	c := Client{
+		UserAgent: "tutor/1.2",
+		Header:    http.Header{"Authorization": {"Bearer token"}},
+	}
+	intercepted := false
+	c.Use(func(*http.Request) error {
+		intercepted = true
+		return nil
+	})
+	data, err := c.fetch(context.Background(), server.URL+"/image", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "GIF", string(data))
+	assert.False(t, intercepted)
+	assert.Empty(t, header.Get("Authorization"))
+	assert.Equal(t, "tutor/1.2", header.Get("User-Agent"))
+	assert.Equal(t, "gzip", header.Get("Accept-Encoding"))
+}
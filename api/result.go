@@ -0,0 +1,250 @@
+package api
+
+import "strings"
+
+// Page returns the nth page (counting from zero) of the result's pods, where
+// each page holds at most size pods. It returns nil if the page is past the
+// end of the result.
+//
+// Some queries return 20 or more pods; paging lets callers show a few at a
+// time rather than flooding a terminal or chat message.
+func (r Result) Page(n, size int) []Pod {
+	if n < 0 || size <= 0 || n*size >= len(r.Pods) {
+		return nil
+	}
+	end := (n + 1) * size
+	if end > len(r.Pods) {
+		end = len(r.Pods)
+	}
+	return r.Pods[n*size : end]
+}
+
+// Pages returns the number of pages of the given size needed to hold all of
+// the result's pods.
+func (r Result) Pages(size int) int {
+	if size <= 0 {
+		return 0
+	}
+	return (len(r.Pods) + size - 1) / size
+}
+
+// InputInterpretation returns the plaintext of the "Input interpretation"
+// pod: how Wolfram Alpha understood the query, like "convert 10 feet to
+// meters", which is worth confirming back to users. It returns an empty
+// string if the result has no such pod. (If the query was reinterpreted, the
+// new query is in Reinterpretation instead.)
+func (r Result) InputInterpretation() string {
+	for _, pod := range r.Pods {
+		if pod.ID == "Input" && len(pod.Subpods) > 0 {
+			return pod.Subpods[0].Plaintext
+		}
+	}
+	return ""
+}
+
+// HasExamplePage reports whether the result has an example page.
+func (r Result) HasExamplePage() bool {
+	return r.ExamplePage != nil
+}
+
+// HasFutureTopic reports whether the result has a future topic.
+func (r Result) HasFutureTopic() bool {
+	return r.FutureTopic != nil
+}
+
+// HasLanguageMessage reports whether the result has a language message.
+func (r Result) HasLanguageMessage() bool {
+	return r.LanguageMessage != nil
+}
+
+// HasReinterpretation reports whether the query was reinterpreted.
+func (r Result) HasReinterpretation() bool {
+	return r.Reinterpretation != nil
+}
+
+// Translated returns the result's translation warning, which UIs can show as
+// "translated from …", or nil if the input wasn't translated (see
+// Client.Translation).
+func (r Result) Translated() *Warning {
+	for i, w := range r.Warnings {
+		if w.Type == "translation" {
+			return &r.Warnings[i]
+		}
+	}
+	return nil
+}
+
+// HasError reports whether the result has an error.
+func (r Result) HasError() bool {
+	return r.Error != nil
+}
+
+// HasError reports whether the pod has an error.
+func (p Pod) HasError() bool {
+	return p.Error != nil
+}
+
+// HasImage reports whether the subpod has an image representation.
+func (s Subpod) HasImage() bool {
+	return s.Image != nil
+}
+
+// HasMathML reports whether the subpod has a MathML representation.
+func (s Subpod) HasMathML() bool {
+	return s.MathML != nil
+}
+
+// Best returns the subpod's first available representation in order of
+// preference, along with its format, so rendering code needs a single call.
+// Without preferences, it prefers MathML, then plaintext, then the image. The
+// representation of an image is its URL, and that of a cell is its
+// expression (see Cell.Expression). It returns false if none of the
+// preferred formats is available.
+func (s Subpod) Best(preferences ...Format) (Format, string, bool) {
+	if len(preferences) == 0 {
+		preferences = []Format{MathMLFormat, PlaintextFormat, ImageF}
+	}
+	for _, f := range preferences {
+		if repr := s.representation(f); repr != "" {
+			return f, repr, true
+		}
+	}
+	return 0, "", false
+}
+
+// representation returns the subpod's representation in the format, or the
+// empty string if it has none.
+func (s Subpod) representation(f Format) string {
+	switch f {
+	case PlaintextFormat:
+		return s.Plaintext
+	case ImageF:
+		if s.Image != nil {
+			return s.Image.URL
+		}
+	case MathMLFormat:
+		if s.MathML != nil {
+			return s.MathML.Xml
+		}
+	case MathematicaInputFormat:
+		return s.MathematicaInput
+	case MathematicaOutputFormat:
+		return s.MathematicaOutput
+	case CellFormat:
+		if s.Cell != nil {
+			expr, _ := s.Cell.Expression()
+			return expr
+		}
+	}
+	return ""
+}
+
+// Unbranded returns a copy of the result without pods that are purely
+// decorative or branded content: links to Wolfram Alpha web pages, like the
+// "Wolfram|Alpha website result for …" pod, and "Image" pods whose images
+// duplicate those of the primary or "Result" pod. It's useful for keeping
+// payloads small for API consumers with strict size limits.
+func (r Result) Unbranded() Result {
+	var primary []*Image
+	for _, pod := range r.Pods {
+		if pod.Primary || pod.ID == "Result" {
+			for _, subpod := range pod.Subpods {
+				if subpod.Image != nil {
+					primary = append(primary, subpod.Image)
+				}
+			}
+		}
+	}
+
+	pods := make([]Pod, 0, len(r.Pods))
+	for _, pod := range r.Pods {
+		if !pod.branded() && !(pod.ID == "Image" && pod.duplicates(primary)) {
+			pods = append(pods, pod)
+		}
+	}
+	r.Pods = pods
+	return r
+}
+
+// branded reports whether the pod only links to Wolfram Alpha's website.
+func (p Pod) branded() bool {
+	return strings.HasPrefix(p.Title, "Wolfram|Alpha") || strings.HasPrefix(p.ID, "WolframAlpha")
+}
+
+// duplicates reports whether the pod has images, all of which are among the
+// given images.
+func (p Pod) duplicates(images []*Image) bool {
+	if len(p.Subpods) == 0 {
+		return false
+	}
+	for _, subpod := range p.Subpods {
+		found := false
+		for _, img := range images {
+			if subpod.Image != nil && sameImage(*subpod.Image, *img) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sameImage reports whether two images are the same, either by URL or by
+// description and size (image URLs differ between pods even for identical
+// images).
+func sameImage(a, b Image) bool {
+	return a.URL == b.URL || a.Alt != "" && a.Alt == b.Alt && a.Width == b.Width && a.Height == b.Height
+}
+
+// A PodGroup is a set of pods sharing the same base ID. See Pod.BaseID.
+type PodGroup struct {
+	// The base ID shared by the pods
+	ID string
+
+	// The pods, in the order they appear in the result
+	Pods []Pod
+}
+
+// BaseID returns the pod ID without any suffix after a colon. Some results
+// contain several pods of the same kind, distinguished by suffixes (e.g.,
+// "Result:ChemicalData" and "Result:ElementData"), which share the base ID
+// "Result".
+func (p Pod) BaseID() string {
+	if i := strings.Index(p.ID, ":"); i >= 0 {
+		return p.ID[:i]
+	}
+	return p.ID
+}
+
+// PodsByID returns all of the result's pods whose ID or base ID matches id,
+// in the order they appear in the result.
+func (r Result) PodsByID(id string) []Pod {
+	var pods []Pod
+	for _, pod := range r.Pods {
+		if pod.ID == id || pod.BaseID() == id {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// PodGroups returns the result's pods grouped by base ID, with groups in the
+// order their first pods appear in the result.
+func (r Result) PodGroups() []PodGroup {
+	var groups []PodGroup
+	index := make(map[string]int)
+	for _, pod := range r.Pods {
+		id := pod.BaseID()
+		i, ok := index[id]
+		if !ok {
+			i = len(groups)
+			index[id] = i
+			groups = append(groups, PodGroup{ID: id})
+		}
+		groups[i].Pods = append(groups[i].Pods, pod)
+	}
+	return groups
+}
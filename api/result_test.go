@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResult_Page(t *testing.T) {
+	result := Result{Pods: []Pod{{ID: "A"}, {ID: "B"}, {ID: "C"}, {ID: "D"}, {ID: "E"}}}
+	assert.Equal(t, []Pod{{ID: "A"}, {ID: "B"}}, result.Page(0, 2))
+	assert.Equal(t, []Pod{{ID: "C"}, {ID: "D"}}, result.Page(1, 2))
+	assert.Equal(t, []Pod{{ID: "E"}}, result.Page(2, 2))
+	assert.Nil(t, result.Page(3, 2))
+	assert.Nil(t, result.Page(-1, 2))
+	assert.Nil(t, result.Page(0, 0))
+}
+
+func TestResult_Pages(t *testing.T) {
+	result := Result{Pods: make([]Pod, 5)}
+	assert.Equal(t, 3, result.Pages(2))
+	assert.Equal(t, 1, result.Pages(5))
+	assert.Equal(t, 0, result.Pages(0))
+	assert.Equal(t, 0, Result{}.Pages(2))
+}
+
+func TestResult_InputInterpretation(t *testing.T) {
+	assert.Equal(t, "convert 10 feet to meters", Result{Pods: []Pod{
+		{ID: "Input", Subpods: []Subpod{{Plaintext: "convert 10 feet to meters"}}},
+		{ID: "Result", Subpods: []Subpod{{Plaintext: "3.048 meters"}}},
+	}}.InputInterpretation())
+	assert.Empty(t, Result{Pods: []Pod{{ID: "Input"}, {ID: "Result"}}}.InputInterpretation())
+}
+
+func TestResult_Has(t *testing.T) {
+	var result Result
+	xml.Unmarshal([]byte(`<queryresult success="true" error="false"></queryresult>`), &result)
+	assert.False(t, result.HasExamplePage())
+	assert.False(t, result.HasFutureTopic())
+	assert.False(t, result.HasLanguageMessage())
+	assert.False(t, result.HasReinterpretation())
+	assert.False(t, result.HasError())
+
+	xml.Unmarshal([]byte(`<queryresult success="false" error="true">
+	                        <error><code>1</code><msg>Invalid appid</msg></error>
+	                        <examplepage category="Calculus" url="http://wolframalpha.com/examples/Calculus"/>
+	                        <futuretopic topic="Operating Systems" msg="Under investigation"/>
+	                        <languagemsg english="No German" other="Kein Deutsch"/>
+	                        <reinterpret text="Using closest interpretation:" new="mustang moon"/>
+	                      </queryresult>`), &result)
+	assert.True(t, result.HasExamplePage())
+	assert.True(t, result.HasFutureTopic())
+	assert.True(t, result.HasLanguageMessage())
+	assert.True(t, result.HasReinterpretation())
+	assert.True(t, result.HasError())
+}
+
+func TestPod_HasError(t *testing.T) {
+	var pod Pod
+	xml.Unmarshal([]byte(`<pod title="Result" error="true"><error><code>1000</code><msg>Timed out</msg></error></pod>`), &pod)
+	assert.True(t, pod.HasError())
+	assert.Equal(t, &Error{Code: 1000, Message: "Timed out"}, pod.Error)
+	assert.False(t, Pod{}.HasError())
+}
+
+func TestSubpod_Has(t *testing.T) {
+	assert.False(t, Subpod{}.HasImage())
+	assert.False(t, Subpod{}.HasMathML())
+	assert.True(t, Subpod{Image: &Image{}}.HasImage())
+	assert.True(t, Subpod{MathML: &MathML{}}.HasMathML())
+}
+
+func TestResult_Translated(t *testing.T) {
+	assert.Nil(t, Result{}.Translated())
+	r := Result{Warnings: []Warning{{Type: "spellcheck"}, {Type: "translation", Language: "German"}}}
+	assert.Equal(t, &r.Warnings[1], r.Translated())
+}
+
+func TestSubpod_Best(t *testing.T) {
+	s := Subpod{
+		Plaintext: "x^2",
+		Image:     &Image{URL: "http://wolframalpha.com/1", Alt: "x^2"},
+		MathML:    &MathML{Xml: "<math><msup><mi>x</mi><mn>2</mn></msup></math>"},
+	}
+
+	f, repr, ok := s.Best()
+	assert.True(t, ok)
+	assert.Equal(t, MathMLFormat, f)
+	assert.Equal(t, s.MathML.Xml, repr)
+
+	f, repr, ok = s.Best(MathematicaInputFormat, ImageF, PlaintextFormat)
+	assert.True(t, ok)
+	assert.Equal(t, ImageF, f)
+	assert.Equal(t, "http://wolframalpha.com/1", repr)
+
+	f, repr, ok = Subpod{Plaintext: "x^2"}.Best()
+	assert.True(t, ok)
+	assert.Equal(t, PlaintextFormat, f)
+	assert.Equal(t, "x^2", repr)
+
+	_, _, ok = s.Best(CellFormat, SoundFormat)
+	assert.False(t, ok)
+}
+
+func TestResult_Unbranded(t *testing.T) {
+	img := &Image{URL: "http://wolframalpha.com/1", Alt: "a cat", Width: 50, Height: 40}
+	result := Result{Pods: []Pod{
+		{ID: "Input"},
+		{ID: "Result", Subpods: []Subpod{{Image: img}}},
+		{ID: "Image", Subpods: []Subpod{{Image: &Image{URL: "http://wolframalpha.com/2", Alt: "a cat", Width: 50, Height: 40}}}},
+		{ID: "Image", Title: "Other image", Subpods: []Subpod{{Image: &Image{URL: "http://wolframalpha.com/3", Alt: "a dog"}}}},
+		{ID: "WolframAlphaWebsite", Title: "Wolfram|Alpha website result for \"cat\""},
+	}}
+	assert.Equal(t, []Pod{
+		{ID: "Input"},
+		{ID: "Result", Subpods: []Subpod{{Image: img}}},
+		{ID: "Image", Title: "Other image", Subpods: []Subpod{{Image: &Image{URL: "http://wolframalpha.com/3", Alt: "a dog"}}}},
+	}, result.Unbranded().Pods)
+	assert.Len(t, result.Pods, 5)
+}
+
+func TestPod_BaseID(t *testing.T) {
+	assert.Equal(t, "Result", Pod{ID: "Result:ChemicalData"}.BaseID())
+	assert.Equal(t, "Result", Pod{ID: "Result"}.BaseID())
+}
+
+func TestResult_PodsByID(t *testing.T) {
+	result := Result{Pods: []Pod{
+		{ID: "Input"},
+		{ID: "Result:ChemicalData"},
+		{ID: "Image"},
+		{ID: "Result:ElementData"},
+	}}
+	assert.Equal(t, []Pod{{ID: "Result:ChemicalData"}, {ID: "Result:ElementData"}}, result.PodsByID("Result"))
+	assert.Equal(t, []Pod{{ID: "Result:ElementData"}}, result.PodsByID("Result:ElementData"))
+	assert.Nil(t, result.PodsByID("Map"))
+}
+
+func TestResult_PodGroups(t *testing.T) {
+	result := Result{Pods: []Pod{
+		{ID: "Input"},
+		{ID: "Result:ChemicalData"},
+		{ID: "Image"},
+		{ID: "Result:ElementData"},
+	}}
+	assert.Equal(t, []PodGroup{
+		{ID: "Input", Pods: []Pod{{ID: "Input"}}},
+		{ID: "Result", Pods: []Pod{{ID: "Result:ChemicalData"}, {ID: "Result:ElementData"}}},
+		{ID: "Image", Pods: []Pod{{ID: "Image"}}},
+	}, result.PodGroups())
+}
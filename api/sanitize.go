@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strings"
+)
+
+const mathMLNamespace = "http://www.w3.org/1998/Math/MathML"
+
+var (
+	// Presentation MathML elements, plus the semantics wrapper. Notably absent
+	// are annotation-xml, which can embed arbitrary HTML, and maction, which
+	// can trigger scripts.
+	mathMLElements = map[string]bool{
+		"math": true, "mi": true, "mn": true, "mo": true, "ms": true,
+		"mtext": true, "mspace": true, "mrow": true, "mfrac": true,
+		"msqrt": true, "mroot": true, "mstyle": true, "merror": true,
+		"mpadded": true, "mphantom": true, "mfenced": true, "menclose": true,
+		"msub": true, "msup": true, "msubsup": true, "munder": true,
+		"mover": true, "munderover": true, "mmultiscripts": true,
+		"mprescripts": true, "none": true, "mtable": true, "mtr": true,
+		"mtd": true, "mlabeledtr": true, "maligngroup": true,
+		"malignmark": true, "semantics": true, "annotation": true,
+	}
+
+	mathMLAttributes = map[string]bool{
+		"mathvariant": true, "mathsize": true, "mathcolor": true,
+		"mathbackground": true, "display": true, "displaystyle": true,
+		"scriptlevel": true, "fence": true, "separator": true,
+		"stretchy": true, "symmetric": true, "largeop": true,
+		"movablelimits": true, "accent": true, "accentunder": true,
+		"lspace": true, "rspace": true, "minsize": true, "maxsize": true,
+		"form": true, "linethickness": true, "numalign": true,
+		"denomalign": true, "bevelled": true, "columnalign": true,
+		"rowalign": true, "columnspan": true, "rowspan": true,
+		"columnlines": true, "rowlines": true, "frame": true, "open": true,
+		"close": true, "separators": true, "notation": true, "width": true,
+		"height": true, "depth": true, "encoding": true,
+	}
+)
+
+// SanitizeMathML returns a copy of the MathML markup that is safe to embed in
+// a web page. Elements outside a presentation MathML allowlist are removed
+// along with their content (so <script> elements can't sneak through), as are
+// attributes outside an allowlist (including all event handlers and links),
+// comments, and processing instructions. Malformed markup yields an empty
+// string.
+func SanitizeMathML(s string) string {
+	var buf bytes.Buffer
+	d := xml.NewDecoder(strings.NewReader(s))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return buf.String()
+		} else if err != nil {
+			return ""
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if !mathMLElements[tok.Name.Local] || tok.Name.Space != "" && tok.Name.Space != mathMLNamespace {
+				if err := d.Skip(); err != nil {
+					return ""
+				}
+				continue
+			}
+			buf.WriteString("<" + tok.Name.Local)
+			for _, attr := range tok.Attr {
+				if attr.Name.Space == "" && mathMLAttributes[attr.Name.Local] {
+					buf.WriteString(" " + attr.Name.Local + `="`)
+					xml.EscapeText(&buf, []byte(attr.Value))
+					buf.WriteString(`"`)
+				}
+			}
+			buf.WriteString(">")
+		case xml.EndElement:
+			buf.WriteString("</" + tok.Name.Local + ">")
+		case xml.CharData:
+			xml.EscapeText(&buf, tok)
+		}
+	}
+}
+
+// HTML returns the MathML content sanitized for embedding in a web page. See
+// SanitizeMathML.
+func (m MathML) HTML() string {
+	return SanitizeMathML(m.Xml)
+}
+
+// safeURL reports whether a URL from a response is safe to link to from a web
+// page: an absolute http or https URL, or a relative URL.
+func safeURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return scheme == "" || scheme == "http" || scheme == "https"
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSanitizeMathML(t *testing.T) {
+	assert.Equal(
+		t,
+		`<math display="block"><mrow><mi>x</mi><mo>=</mo><mn>0</mn></mrow></math>`,
+		SanitizeMathML(`<math xmlns="http://www.w3.org/1998/Math/MathML" display="block"><mrow><mi>x</mi><mo>=</mo><mn>0</mn></mrow></math>`),
+	)
+	assert.Equal(
+		t,
+		`<math><mi mathvariant="bold">x</mi></math>`,
+		SanitizeMathML(`<math><mi mathvariant="bold" onclick="alert(1)" href="javascript:alert(1)">x</mi><script>alert(1)</script><!-- hi --></math>`),
+	)
+	assert.Equal(
+		t,
+		`<math><semantics><mi>x</mi></semantics></math>`,
+		SanitizeMathML(`<math><semantics><mi>x</mi><annotation-xml encoding="text/html"><img src="x" onerror="alert(1)"/></annotation-xml></semantics></math>`),
+	)
+	assert.Equal(t, `<mtext>a &lt; b</mtext>`, SanitizeMathML(`<mtext>a &lt; b</mtext>`))
+	assert.Equal(t, "", SanitizeMathML(`<math><mi>x</math>`))
+}
+
+func TestMathML_HTML(t *testing.T) {
+	assert.Equal(t, `<math>4 dx</math>`, MathML{Xml: `<math>4 dx<script>alert(1)</script></math>`}.HTML())
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A Scrubber removes personal data, like email addresses, from query input
+// before it's sent to Wolfram Alpha. When a Client has Scrubbers, QueryContext
+// runs the input through each of them in order, and records what they removed
+// in Result.Redactions for auditing.
+type Scrubber interface {
+	// Scrub returns the input with personal data removed, and what was
+	// removed.
+	Scrub(input string) (string, []Redaction)
+}
+
+// The ScrubberFunc type is an adapter to allow the use of ordinary functions
+// as Scrubbers.
+type ScrubberFunc func(input string) (string, []Redaction)
+
+// Scrub calls f(input).
+func (f ScrubberFunc) Scrub(input string) (string, []Redaction) {
+	return f(input)
+}
+
+// A Redaction records a piece of personal data removed from query input.
+type Redaction struct {
+	// The kind of data, like "email"
+	Kind string
+
+	// The text that was removed
+	Text string
+}
+
+// A PatternScrubber is a Scrubber that removes every match of a regular
+// expression.
+type PatternScrubber struct {
+	// The kind of data the pattern matches, like "email"
+	Kind string
+
+	// The pattern to remove
+	Pattern *regexp.Regexp
+}
+
+var (
+	// EmailScrubber removes email addresses.
+	EmailScrubber = PatternScrubber{
+		Kind:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`),
+	}
+
+	// PhoneScrubber removes phone numbers written with separators, like
+	// "(555) 123-4567" or "+44 20 7946 0958". Bare runs of digits are left
+	// alone, since they're usually numbers to compute with.
+	PhoneScrubber = PatternScrubber{
+		Kind:    "phone",
+		Pattern: regexp.MustCompile(`(?:\+[0-9]{1,3}[ .\-]?)?(?:\([0-9]{2,4}\)|[0-9]{2,4})[ .\-][0-9]{3,4}[ .\-][0-9]{3,4}\b`),
+	}
+)
+
+// Scrub removes the matches of the pattern from the input.
+func (s PatternScrubber) Scrub(input string) (string, []Redaction) {
+	var redactions []Redaction
+	input = s.Pattern.ReplaceAllStringFunc(input, func(match string) string {
+		redactions = append(redactions, Redaction{Kind: s.Kind, Text: match})
+		return ""
+	})
+	return tidySpaces(input), redactions
+}
+
+// NameScrubber returns a Scrubber that removes the names found by the given
+// function, which might consult a named-entity recognizer or a list of the
+// user's contacts. Names are removed wherever they occur in the input, ignoring
+// case.
+func NameScrubber(names func(input string) []string) Scrubber {
+	return ScrubberFunc(func(input string) (string, []Redaction) {
+		var redactions []Redaction
+		for _, name := range names(input) {
+			if strings.TrimSpace(name) == "" {
+				continue
+			}
+			pattern := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(name))
+			input = pattern.ReplaceAllStringFunc(input, func(match string) string {
+				redactions = append(redactions, Redaction{Kind: "name", Text: match})
+				return ""
+			})
+		}
+		return tidySpaces(input), redactions
+	})
+}
+
+// scrub runs the input through the client's scrubbers.
+func (c Client) scrub(input string) (string, []Redaction) {
+	var redactions []Redaction
+	for _, s := range c.Scrubbers {
+		var r []Redaction
+		input, r = s.Scrub(input)
+		redactions = append(redactions, r...)
+	}
+	return input, redactions
+}
+
+// tidySpaces collapses the runs of spaces left behind by removed text.
+func tidySpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
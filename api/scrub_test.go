@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEmailScrubber(t *testing.T) {
+	input, redactions := EmailScrubber.Scrub("email jane.doe+wa@mail.example.co.uk the integral of x^2")
+	assert.Equal(t, "email the integral of x^2", input)
+	assert.Equal(t, []Redaction{{Kind: "email", Text: "jane.doe+wa@mail.example.co.uk"}}, redactions)
+
+	input, redactions = EmailScrubber.Scrub("2 + 2")
+	assert.Equal(t, "2 + 2", input)
+	assert.Empty(t, redactions)
+}
+
+func TestPhoneScrubber(t *testing.T) {
+	for _, phone := range []string{"(555) 123-4567", "555-123-4567", "555.123.4567", "+44 20 7946 0958", "+1 555 123 4567"} {
+		input, redactions := PhoneScrubber.Scrub("call " + phone + " at 3pm")
+		assert.Equal(t, "call at 3pm", input, phone)
+		assert.Equal(t, []Redaction{{Kind: "phone", Text: phone}}, redactions, phone)
+	}
+	for _, input := range []string{"12345678 * 9", "100 - 25 - 30", "3.14159265", "1,234,567"} {
+		scrubbed, redactions := PhoneScrubber.Scrub(input)
+		assert.Equal(t, input, scrubbed)
+		assert.Empty(t, redactions)
+	}
+}
+
+func TestNameScrubber(t *testing.T) {
+	s := NameScrubber(func(string) []string { return []string{"Ada Lovelace", ""} })
+	input, redactions := s.Scrub("how old would ada lovelace be today")
+	assert.Equal(t, "how old would be today", input)
+	assert.Equal(t, []Redaction{{Kind: "name", Text: "ada lovelace"}}, redactions)
+}
+
+func TestClient_Query_scrubbers(t *testing.T) {
+	params := serve(t, resultXML)
+	c := Client{Scrubbers: []Scrubber{EmailScrubber, PhoneScrubber}}
+	result, err := c.Query("I'm bob@example.com, 555-123-4567: convert 10 feet to meters")
+	assert.NoError(t, err)
+	assert.Equal(t, "I'm , : convert 10 feet to meters", params.Get("input"))
+	assert.Equal(t, []Redaction{
+		{Kind: "email", Text: "bob@example.com"},
+		{Kind: "phone", Text: "555-123-4567"},
+	}, result.Redactions)
+
+	result, err = c.Query("bob@example.com")
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	assert.Equal(t, []Redaction{{Kind: "email", Text: "bob@example.com"}}, result.Redactions)
+}
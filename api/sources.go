@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// A SourceGroup is a set of sources about the same topic. See Source.Topic.
+type SourceGroup struct {
+	// The topic shared by the sources
+	Topic string
+
+	// The sources, in the order they appear in the result
+	Sources []Source
+}
+
+// Topic returns the general topic the source's web page is about, taken from
+// its URL: for example, "CityData" for
+// http://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html.
+// If the URL doesn't follow Wolfram Alpha's naming scheme, the topic is the
+// source's description.
+func (s Source) Topic() string {
+	u, err := url.Parse(s.URL)
+	if err == nil {
+		name := strings.TrimSuffix(path.Base(u.Path), path.Ext(u.Path))
+		if topic := strings.TrimSuffix(name, "SourceInformationNotes"); topic != name && topic != "" {
+			return topic
+		}
+	}
+	return s.Description
+}
+
+// UniqueSources returns the result's sources with duplicates removed. Sources
+// are duplicates if their URLs are the same, ignoring the scheme, the case of
+// the host, trailing slashes, and fragments; only the first is kept, even if
+// the others have different descriptions.
+func (r Result) UniqueSources() []Source {
+	var sources []Source
+	seen := make(map[string]bool)
+	for _, src := range r.Sources {
+		key := normalizeSourceURL(src.URL)
+		if !seen[key] {
+			seen[key] = true
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
+
+// SourceGroups returns the result's unique sources grouped by topic, with
+// groups in the order their first sources appear in the result.
+func (r Result) SourceGroups() []SourceGroup {
+	var groups []SourceGroup
+	index := make(map[string]int)
+	for _, src := range r.UniqueSources() {
+		topic := src.Topic()
+		i, ok := index[topic]
+		if !ok {
+			i = len(groups)
+			index[topic] = i
+			groups = append(groups, SourceGroup{Topic: topic})
+		}
+		groups[i].Sources = append(groups[i].Sources, src)
+	}
+	return groups
+}
+
+func normalizeSourceURL(s string) string {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return s
+	}
+	u.Scheme = ""
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.Fragment = ""
+	return u.String()
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSource_Topic(t *testing.T) {
+	assert.Equal(t, "CityData", Source{
+		URL:         "http://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html",
+		Description: "City data",
+	}.Topic())
+	assert.Equal(t, "City data", Source{
+		URL:         "http://example.com/cities",
+		Description: "City data",
+	}.Topic())
+}
+
+func TestResult_UniqueSources(t *testing.T) {
+	result := Result{Sources: []Source{
+		{URL: "http://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html", Description: "City data"},
+		{URL: "https://WWW.wolframalpha.com/sources/CityDataSourceInformationNotes.html#notes", Description: "City data from Wolfram"},
+		{URL: "http://www.wolframalpha.com/sources/CountryDataSourceInformationNotes.html", Description: "Country data"},
+	}}
+	assert.Equal(t, []Source{
+		{URL: "http://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html", Description: "City data"},
+		{URL: "http://www.wolframalpha.com/sources/CountryDataSourceInformationNotes.html", Description: "Country data"},
+	}, result.UniqueSources())
+}
+
+func TestResult_SourceGroups(t *testing.T) {
+	result := Result{Sources: []Source{
+		{URL: "http://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html", Description: "City data"},
+		{URL: "http://www.wolframalpha.com/sources/CountryDataSourceInformationNotes.html", Description: "Country data"},
+		{URL: "http://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html/", Description: "City data"},
+		{URL: "http://www3.wolframalpha.com/sources/CityDataSourceInformationNotes.html", Description: "City data (mirror)"},
+	}}
+	assert.Equal(t, []SourceGroup{
+		{Topic: "CityData", Sources: []Source{
+			{URL: "http://www.wolframalpha.com/sources/CityDataSourceInformationNotes.html", Description: "City data"},
+			{URL: "http://www3.wolframalpha.com/sources/CityDataSourceInformationNotes.html", Description: "City data (mirror)"},
+		}},
+		{Topic: "CountryData", Sources: []Source{
+			{URL: "http://www.wolframalpha.com/sources/CountryDataSourceInformationNotes.html", Description: "Country data"},
+		}},
+	}, result.SourceGroups())
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// transportConfig holds the Client options that configure its transport.
+type transportConfig struct {
+	proxy               string
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	forceHTTP2          bool
+}
+
+// transports holds a transport for each configuration in use, so that
+// Clients with the same options share connections instead of re-dialing.
+var transports sync.Map
+
+// httpClient returns the HTTP client used to make requests.
+func (c Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+
+	cfg := transportConfig{
+		maxIdleConns:        c.MaxIdleConns,
+		maxIdleConnsPerHost: c.MaxIdleConnsPerHost,
+		idleConnTimeout:     c.IdleConnTimeout,
+		forceHTTP2:          c.ForceHTTP2,
+	}
+	if c.Proxy != nil {
+		cfg.proxy = c.Proxy.String()
+	}
+	if cfg == (transportConfig{}) {
+		return http.DefaultClient
+	}
+
+	t, ok := transports.Load(cfg)
+	if !ok {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if c.Proxy != nil {
+			transport.Proxy = http.ProxyURL(c.Proxy)
+		}
+		if cfg.maxIdleConns != 0 {
+			transport.MaxIdleConns = cfg.maxIdleConns
+		}
+		if cfg.maxIdleConnsPerHost != 0 {
+			transport.MaxIdleConnsPerHost = cfg.maxIdleConnsPerHost
+		}
+		if cfg.idleConnTimeout != 0 {
+			transport.IdleConnTimeout = cfg.idleConnTimeout
+		}
+		if cfg.forceHTTP2 {
+			transport.Protocols = new(http.Protocols)
+			transport.Protocols.SetHTTP2(true)
+		}
+		t, _ = transports.LoadOrStore(cfg, transport)
+	}
+	return &http.Client{Transport: t.(*http.Transport)}
+}
+
+// An Interceptor inspects or modifies an outgoing request before it's sent,
+// for example to add tracing headers, sign the request, or rewrite its host to
+// point at a staging mirror. If it returns an error, the request isn't sent
+// and the error is returned to the caller.
+type Interceptor func(req *http.Request) error
+
+// Use appends interceptors to the client's chain, to be run on every request
+// it sends to the API endpoint, after the client's own headers are set.
+// Secondary fetches, like image downloads, don't run the chain. Copies of the
+// client made earlier keep their own chain.
+func (c *Client) Use(interceptors ...Interceptor) {
+	c.Interceptors = append(c.Interceptors[:len(c.Interceptors):len(c.Interceptors)], interceptors...)
+}
+
+// do sends the request with the client's headers and interceptors (logging
+// the exchange if the client has a Debug writer), asking for a
+// gzip-compressed response, and returns the response with its body
+// transparently decompressed. The Accept-Encoding header is set explicitly
+// (rather than left to http.Transport) so that responses are compressed even
+// with custom transports.
+func (c Client) do(req *http.Request) (*http.Response, error) {
+	for key, values := range c.Header {
+		req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	for _, intercept := range c.Interceptors {
+		if err := intercept(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.Debug != nil {
+		c.dumpRequest(req)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		if c.Debug != nil {
+			fmt.Fprintf(c.Debug, "< error: %v\n\n", err)
+		}
+		return nil, err
+	}
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		resp.Body = gzipBody{zr, resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+	}
+	if c.Debug != nil {
+		return c.dumpResponse(resp)
+	}
+	return resp, nil
+}
+
+// gzipBody is a decompressing response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (b gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
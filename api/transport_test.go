@@ -0,0 +1,138 @@
+package api
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestClient_Proxy(t *testing.T) {
+	var host, auth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, auth = r.URL.Host, r.Header.Get("Proxy-Authorization")
+		w.Write([]byte(resultXML))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	proxyURL.User = url.UserPassword("user", "pass")
+	c := Client{Endpoint: "http://api.wolframalpha.test/v2/query", Proxy: proxyURL}
+	_, err := c.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, "api.wolframalpha.test", host)
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:pass")), auth)
+
+	// Clients with the same options share a transport.
+	assert.Same(t, c.httpClient().Transport, Client{Proxy: proxyURL}.httpClient().Transport)
+	assert.Same(t, http.DefaultClient, Client{}.httpClient())
+}
+
+func TestClient_gzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.Write([]byte(resultXML))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(resultXML))
+		zw.Close()
+	}))
+	defer server.Close()
+
+	// A custom transport doesn't decompress responses itself.
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "gzip", req.Header.Get("Accept-Encoding"))
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	for _, c := range []Client{
+		{Endpoint: server.URL},
+		{Endpoint: server.URL, HTTPClient: &http.Client{Transport: transport}},
+	} {
+		result, err := c.Query("pi")
+		assert.NoError(t, err)
+		assert.Len(t, result.Pods, 2)
+
+		data, err := c.fetch(context.Background(), server.URL, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, resultXML, string(data))
+	}
+}
+
+func TestClient_transportOptions(t *testing.T) {
+	c := Client{MaxIdleConns: 200, MaxIdleConnsPerHost: 100, IdleConnTimeout: time.Minute, ForceHTTP2: true}
+	transport := c.httpClient().Transport.(*http.Transport)
+	assert.Equal(t, 200, transport.MaxIdleConns)
+	assert.Equal(t, 100, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.True(t, transport.Protocols.HTTP2())
+	assert.False(t, transport.Protocols.HTTP1())
+	assert.Same(t, transport, c.httpClient().Transport)
+
+	transport = Client{MaxIdleConnsPerHost: 10}.httpClient().Transport.(*http.Transport)
+	assert.Equal(t, http.DefaultTransport.(*http.Transport).MaxIdleConns, transport.MaxIdleConns)
+	assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+	assert.Nil(t, transport.Protocols)
+}
+
+func TestClient_headers(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+
+	c := Client{
+		Endpoint:  server.URL,
+		UserAgent: "tutor/1.2 (+https://example.com)",
+		Header:    http.Header{"X-Request-Id": {"42"}, "accept-language": {"de"}},
+	}
+	_, err := c.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, "tutor/1.2 (+https://example.com)", header.Get("User-Agent"))
+	assert.Equal(t, "42", header.Get("X-Request-Id"))
+	assert.Equal(t, "de", header.Get("Accept-Language"))
+
+	_, err = Client{Endpoint: server.URL}.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go-http-client/1.1", header.Get("User-Agent"))
+}
+
+func TestClient_Use(t *testing.T) {
+	var header http.Header
+	staging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(resultXML))
+	}))
+	defer staging.Close()
+	stagingURL, _ := url.Parse(staging.URL)
+
+	c := Client{Endpoint: "http://api.invalid/v2/query"}
+	c.Use(func(req *http.Request) error {
+		req.URL.Host = stagingURL.Host
+		return nil
+	})
+	d := c
+	c.Use(func(req *http.Request) error {
+		req.Header.Set("Traceparent", "00-1-2-01")
+		return nil
+	})
+	assert.Len(t, d.Interceptors, 1)
+
+	_, err := c.Query("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, "00-1-2-01", header.Get("Traceparent"))
+
+	denied := errors.New("denied")
+	c.Use(func(*http.Request) error { return denied })
+	_, err = c.Query("pi")
+	assert.True(t, errors.Is(err, denied))
+}
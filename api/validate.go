@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strings"
+)
+
+// The default Validate Query API endpoint
+var validateURL = "https://api.wolframalpha.com/v2/validatequery"
+
+// A ValidationResult represents the Wolfram Alpha API's response to a
+// validation request, which checks whether Wolfram Alpha can understand a
+// query without computing any results. See Client.Validate.
+type ValidationResult struct {
+	// The tag name
+	XMLName struct{} `xml:"validatequeryresult"`
+
+	// Whether the query was understood
+	Success bool `xml:"success,attr"`
+
+	// Whether there was an error processing the request
+	Errored bool `xml:"error,attr"`
+
+	// The error, if the request couldn't be processed
+	Error *Error `xml:"error"`
+
+	// The wall clock time to validate the query, in seconds
+	Timing float32 `xml:"timing,attr"`
+
+	// The wall clock time to parse the query, in seconds
+	ParseTiming float32 `xml:"parsetiming,attr"`
+
+	// The API version
+	Version string `xml:"version,attr"`
+
+	// The assumptions Wolfram Alpha would make to interpret the query
+	Assumptions []Assumption `xml:"assumptions>assumption"`
+
+	// Warnings about how the query was read, like spelling corrections
+	// (filled in by Client.Validate)
+	Warnings []Warning `xml:"-"`
+}
+
+// A Warning describes an adjustment Wolfram Alpha made to read a query, such
+// as correcting its spelling or translating it.
+type Warning struct {
+	// The kind of warning, like "spellcheck", "delimiters", "translation", or
+	// "reinterpret"
+	Type string
+
+	// A message that could be displayed to the user, like "Interpreting
+	// "pai" as "pi""
+	Text string
+
+	// The word that was adjusted, for spellcheck warnings
+	Word string
+
+	// The replacement word, for spellcheck warnings
+	Suggestion string
+
+	// The original phrase, its English translation, and the language it was
+	// translated from, for translation warnings
+	Phrase      string
+	Translation string
+	Language    string
+}
+
+// decodeWarnings decodes the warnings of any kind in a query or validation
+// response. Each kind of warning is a differently named element, so they're
+// collected with an "any" field and given their element name as their Type.
+func decodeWarnings(body []byte) []Warning {
+	var raw struct {
+		Warnings struct {
+			Items []struct {
+				XMLName    xml.Name
+				Text       string `xml:"text,attr"`
+				Word       string `xml:"word,attr"`
+				Suggestion string `xml:"suggestion,attr"`
+				Phrase     string `xml:"phrase,attr"`
+				Trans      string `xml:"trans,attr"`
+				Lang       string `xml:"lang,attr"`
+			} `xml:",any"`
+		} `xml:"warnings"`
+	}
+	xml.Unmarshal(body, &raw)
+
+	var warnings []Warning
+	for _, w := range raw.Warnings.Items {
+		warnings = append(warnings, Warning{
+			Type:        w.XMLName.Local,
+			Text:        w.Text,
+			Word:        w.Word,
+			Suggestion:  w.Suggestion,
+			Phrase:      w.Phrase,
+			Translation: w.Trans,
+			Language:    w.Lang,
+		})
+	}
+	return warnings
+}
+
+// Validate checks whether Wolfram Alpha can understand the input, without
+// spending a full query. It is equivalent to ValidateContext with a
+// background context.
+func (c Client) Validate(input string) (ValidationResult, error) {
+	return c.ValidateContext(context.Background(), input)
+}
+
+// ValidateContext checks whether Wolfram Alpha can understand the input,
+// using the Validate Query API, which is much cheaper than a full query. The
+// result reports whether the input was understood, the assumptions that would
+// be made, and any warnings. As with QueryContext, the input is scrubbed and
+// checked first, the request is subject to the client's Timeout (reported as
+// a *TimeoutError), and if Wolfram Alpha can't process the request, the
+// result is returned along with its *Error. Only the AppID, the input, and
+// the client's location and unit system are sent.
+func (c Client) ValidateContext(ctx context.Context, input string) (ValidationResult, error) {
+	c, err := c.resolve(ctx, nil)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	result, err := c.validate(ctx, input)
+	if isTimeout(err) {
+		err = &TimeoutError{Timeout: c.Timeout, Err: err}
+	}
+	return result, err
+}
+
+// validate implements ValidateContext, without timeout handling.
+func (c Client) validate(ctx context.Context, input string) (result ValidationResult, err error) {
+	input, _ = c.scrub(input)
+	if err := CheckInput(input); err != nil {
+		return result, err
+	}
+	if err := c.CheckConfig(); err != nil {
+		return result, err
+	}
+
+	v := url.Values{}
+	v.Set("appid", c.AppID)
+	v.Set("input", input)
+	c.setLocale(v)
+	if c.AppIDs != nil {
+		id := c.AppIDs.Next()
+		v.Set("appid", id)
+		defer func() { c.AppIDs.Report(id, err) }()
+	}
+	body, _, err := c.request(ctx, c.validateEndpoint(), v)
+	if err != nil {
+		return result, err
+	}
+	if err := decode(body, Fingerprint(v), &result); err != nil {
+		return result, err
+	}
+	result.Warnings = decodeWarnings(body)
+	if result.Error != nil {
+		return result, result.Error
+	}
+	return result, nil
+}
+
+// validateEndpoint returns the URL of the Validate Query API endpoint the
+// client uses. For a custom Endpoint ending in "/query", like a proxy's
+// "/v2/query", it's the sibling "/validatequery"; for other custom endpoints,
+// "/validatequery" is appended.
+func (c Client) validateEndpoint() string {
+	if c.Endpoint == "" {
+		return validateURL
+	}
+	if strings.HasSuffix(c.Endpoint, "/query") {
+		return strings.TrimSuffix(c.Endpoint, "query") + "validatequery"
+	}
+	return strings.TrimSuffix(c.Endpoint, "/") + "/validatequery"
+}
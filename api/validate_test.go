@@ -0,0 +1,116 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const validationXML = `
+  <validatequeryresult success="true" error="false" timing="0.041" parsetiming="0.04" version="2.1">
+    <assumptions count="1">
+      <assumption type="Clash" word="mercury" count="2">
+        <value name="Planet" desc="a planet" input="*C.mercury-_*Planet-"/>
+        <value name="Element" desc="a chemical element" input="*C.mercury-_*Element-"/>
+      </assumption>
+    </assumptions>
+    <warnings>
+      <spellcheck word="mercurey" suggestion="mercury" text="Interpreting &quot;mercurey&quot; as &quot;mercury&quot;"/>
+      <delimiters text="An attempt was made to fix mismatched parentheses."/>
+    </warnings>
+  </validatequeryresult>`
+
+func TestClient_Validate(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(validationXML))
+	}))
+	defer server.Close()
+
+	result, err := Client{Endpoint: server.URL + "/v2/query"}.Validate("mercurey (")
+	assert.NoError(t, err)
+	assert.Equal(t, "/v2/validatequery", path)
+	assert.True(t, result.Success)
+	assert.Equal(t, float32(0.04), result.ParseTiming)
+	assert.Equal(t, []Assumption{{
+		Type: "Clash",
+		Word: "mercury",
+		Values: []AssumptionValue{
+			{Name: "Planet", Description: "a planet", Input: "*C.mercury-_*Planet-"},
+			{Name: "Element", Description: "a chemical element", Input: "*C.mercury-_*Element-"},
+		},
+	}}, result.Assumptions)
+	assert.Equal(t, []Warning{
+		{Type: "spellcheck", Text: `Interpreting "mercurey" as "mercury"`, Word: "mercurey", Suggestion: "mercury"},
+		{Type: "delimiters", Text: "An attempt was made to fix mismatched parentheses."},
+	}, result.Warnings)
+}
+
+func TestClient_Validate_errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<validatequeryresult success="false" error="true">
+		                  <error><code>1</code><msg>Invalid appid</msg></error>
+		                </validatequeryresult>`))
+	}))
+	defer server.Close()
+
+	result, err := Client{Endpoint: server.URL}.Validate("pi")
+	assert.Equal(t, &Error{Code: 1, Message: "Invalid appid"}, err)
+	assert.True(t, result.Errored)
+
+	_, err = Client{}.Validate("")
+	assert.ErrorIs(t, err, ErrEmptyInput)
+}
+
+func TestClient_Validate_params(t *testing.T) {
+	var params url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params = r.URL.Query()
+		w.Write([]byte(validationXML))
+	}))
+	defer server.Close()
+
+	c := Client{
+		AppID:         "XXXX",
+		Endpoint:      server.URL,
+		Formats:       []Format{PlaintextFormat},
+		IncludePodIDs: []string{"Result"},
+		Location:      "Madrid",
+		Units:         Metric,
+		Async:         true,
+	}
+	_, err := c.Validate("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"appid":    {"XXXX"},
+		"input":    {"pi"},
+		"location": {"Madrid"},
+		"units":    {"metric"},
+	}, params)
+}
+
+func TestClient_Validate_timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	_, err := Client{Endpoint: server.URL, Timeout: 20 * time.Millisecond}.Validate("pi")
+	var timeoutErr *TimeoutError
+	if assert.ErrorAs(t, err, &timeoutErr) {
+		assert.Equal(t, 20*time.Millisecond, timeoutErr.Timeout)
+	}
+}
+
+func TestClient_validateEndpoint(t *testing.T) {
+	assert.Equal(t, validateURL, Client{}.validateEndpoint())
+	assert.Equal(t, "https://example.com/v2/validatequery", Client{Endpoint: "https://example.com/v2/query"}.validateEndpoint())
+	assert.Equal(t, "https://example.com/wa/validatequery", Client{Endpoint: "https://example.com/wa/"}.validateEndpoint())
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	shortAnswersURL = "https://api.wolframalpha.com/v1/result"
+
+	// The first number in an answer, like "3.048" in "3.048 meters"
+	answerNumberPattern = regexp.MustCompile(`[-−]?[0-9](?:[0-9,]*[0-9])?(?:\.([0-9]+))?(?:×10\^([-−]?[0-9]+))?`)
+
+	// A Short Answers API error, like "Error 1: Invalid appid"
+	shortAnswerErrorPattern = regexp.MustCompile(`^Error ([0-9]+): (.*)$`)
+)
+
+// AskVerified is like Ask, but double-checks the answer. It is equivalent to
+// AskVerifiedContext with a background context.
+func (c Client) AskVerified(input string) (string, error) {
+	return c.AskVerifiedContext(context.Background(), input)
+}
+
+// AskVerifiedContext is like AskContext, but asks twice and returns a
+// *DiscrepancyError if the answers disagree, for applications that must not
+// pass along a transient upstream glitch. The second answer comes from the
+// Short Answers API if VerifyShortAnswers is set, and from a second full
+// query otherwise.
+//
+// Answers containing numbers agree if their first numbers are equal when
+// rounded to the precision of the less precise one, so "3.048 meters" agrees
+// with the short answer "about 3.05 meters". Other answers must be identical.
+func (c Client) AskVerifiedContext(ctx context.Context, input string) (string, error) {
+	answer, err := c.AskContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	var check string
+	if c.VerifyShortAnswers {
+		check, err = c.shortAnswer(ctx, input)
+	} else {
+		check, err = c.AskContext(ctx, input)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if !answersAgree(answer, check) {
+		return "", &DiscrepancyError{Input: input, Answer: answer, Check: check}
+	}
+	return answer, nil
+}
+
+// shortAnswer asks the Short Answers API for a one-line answer to the input,
+// scrubbing and checking the input first like QueryContext.
+func (c Client) shortAnswer(ctx context.Context, input string) (answer string, err error) {
+	c, err = c.resolve(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	input, _ = c.scrub(input)
+	if err := CheckInput(input); err != nil {
+		return "", err
+	}
+	v := url.Values{}
+	v.Set("appid", c.AppID)
+	if c.AppIDs != nil {
+		id := c.AppIDs.Next()
+		v.Set("appid", id)
+		defer func() { c.AppIDs.Report(id, err) }()
+	}
+	v.Set("i", input)
+	switch c.Units {
+	case Imperial:
+		v.Set("units", "imperial")
+	case Metric:
+		v.Set("units", "metric")
+	}
+
+	body, _, err := c.request(ctx, c.shortAnswersEndpoint(), v)
+	message := strings.TrimSpace(string(body))
+	if err != nil && body != nil {
+		if m := shortAnswerErrorPattern.FindStringSubmatch(message); m != nil {
+			code, _ := strconv.Atoi(m[1])
+			return "", &Error{Code: code, Message: m[2]}
+		}
+		return "", fmt.Errorf("%w: %s", err, message)
+	}
+	if err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+// shortAnswersEndpoint returns the URL of the Short Answers API endpoint the
+// client uses. If the client has an Endpoint, the Short Answers API is
+// reached through the same host, at the endpoint's path with "v2/query"
+// replaced by "v1/result", or at /v1/result.
+func (c Client) shortAnswersEndpoint() string {
+	if c.Endpoint == "" {
+		return shortAnswersURL
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return c.Endpoint
+	}
+	if strings.HasSuffix(u.Path, "v2/query") {
+		u.Path = strings.TrimSuffix(u.Path, "v2/query") + "v1/result"
+	} else {
+		u.Path = "/v1/result"
+	}
+	return u.String()
+}
+
+// answersAgree reports whether two answers to the same query agree (see
+// AskVerifiedContext).
+func answersAgree(a, b string) bool {
+	ma, mb := answerNumberPattern.FindStringSubmatch(a), answerNumberPattern.FindStringSubmatch(b)
+	if ma == nil || mb == nil {
+		return ma == nil && mb == nil && a == b
+	}
+	x, errA := ParseNumber(ma[0], LocaleEnglish)
+	y, errB := ParseNumber(mb[0], LocaleEnglish)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	// Allow for rounding to half of the coarser number's last decimal place,
+	// with a little slack for floating-point error.
+	tolerance := math.Max(lastPlace(ma), lastPlace(mb)) / 2
+	return math.Abs(x-y) <= tolerance*(1+1e-9)
+}
+
+// lastPlace returns the value of the last decimal place of a number matched
+// by answerNumberPattern, like 0.01 for "3.05" or 1e6 for "3.3×10^7".
+func lastPlace(m []string) float64 {
+	exp := -len(m[1])
+	if m[2] != "" {
+		e, _ := strconv.Atoi(strings.Replace(m[2], "−", "-", 1))
+		exp += e
+	}
+	return math.Pow(10, float64(exp))
+}
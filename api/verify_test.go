@@ -0,0 +1,77 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAnswersAgree(t *testing.T) {
+	assert.True(t, answersAgree("3.048 meters", "3.048 meters"))
+	assert.True(t, answersAgree("3.048 meters", "about 3.05 meters"))
+	assert.True(t, answersAgree("1,234,567 people", "1234567 people"))
+	assert.True(t, answersAgree("3.3×10^8 m/s", "3.34×10^8 m/s"))
+	assert.True(t, answersAgree("Paris", "Paris"))
+	assert.False(t, answersAgree("3.048 meters", "3.1 meters"))
+	assert.False(t, answersAgree("3.3×10^−5", "3.5×10^−5"))
+	assert.False(t, answersAgree("Paris", "Lyon"))
+	assert.False(t, answersAgree("Paris", "2 cities"))
+}
+
+func TestClient_AskVerified(t *testing.T) {
+	answers := []string{"3.048 meters", "3.048 meters", "30.48 meters"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		answer := answers[0]
+		answers = answers[1:]
+		w.Write([]byte(strings.Replace(resultXML, "3.048 meters", answer, 1)))
+	}))
+	defer server.Close()
+
+	c := Client{Endpoint: server.URL}
+	answer, err := c.AskVerified("10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.048 meters", answer)
+
+	answers = []string{"3.048 meters", "30.48 meters"}
+	_, err = c.AskVerified("10 feet in meters")
+	assert.Equal(t, &DiscrepancyError{
+		Input:  "10 feet in meters",
+		Answer: "3.048 meters",
+		Check:  "30.48 meters",
+	}, err)
+	assert.EqualError(t, err, `api: answers to "10 feet in meters" disagree: "3.048 meters" and "30.48 meters"`)
+}
+
+func TestClient_AskVerified_shortAnswers(t *testing.T) {
+	serve(t, resultXML)
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		if r.URL.Query().Get("i") == "nonsense" {
+			w.WriteHeader(http.StatusNotImplemented)
+			w.Write([]byte("No short answer available"))
+			return
+		}
+		w.Write([]byte("about 3.05 meters\n"))
+	}))
+	defer server.Close()
+	orig := shortAnswersURL
+	shortAnswersURL = server.URL
+	defer func() { shortAnswersURL = orig }()
+
+	c := Client{AppID: "XXXX", Units: Metric, VerifyShortAnswers: true}
+	answer, err := c.AskVerified("10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.048 meters", answer)
+	assert.Equal(t, "appid=XXXX&i=10+feet+in+meters&units=metric", query)
+
+	_, err = c.AskVerified("nonsense")
+	assert.EqualError(t, err, "api: unexpected response status 501 Not Implemented: No short answer available")
+
+	c.Scrubbers = []Scrubber{EmailScrubber}
+	_, err = c.AskVerified("10 feet bob@example.com in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, "appid=XXXX&i=10+feet+in+meters&units=metric", query)
+}
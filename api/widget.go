@@ -0,0 +1,97 @@
+package api
+
+import (
+	"html/template"
+	"strings"
+)
+
+// A Widget renders results as embeddable HTML answer cards: each pod is a
+// collapsible section, images load lazily, and the query's assumptions become
+// dropdowns that re-query through a form. The card is plain HTML with
+// "wolfram-" class names for styling, and needs no JavaScript.
+type Widget struct {
+	// The URL the assumption form is submitted to (with GET), usually the
+	// page rendering the widget. It receives the query as the "input"
+	// parameter and the chosen assumptions as "assumption" parameters, which
+	// can be passed to the next query with WithAssumptions.
+	Endpoint string
+
+	// If true, every pod starts expanded. Otherwise only the input
+	// interpretation and the primary pod do.
+	ExpandAll bool
+}
+
+var widgetTemplate = template.Must(template.New("widget").Parse(strings.TrimSpace(`
+<div class="wolfram-answer">
+{{- if .Assumptions}}
+<form class="wolfram-assumptions" method="get" action="{{.Endpoint}}">
+<input type="hidden" name="input" value="{{.Input}}">
+{{- range .Assumptions}}
+<label>{{.Word}} <select name="assumption">
+{{- range .Values}}
+<option value="{{.Input}}">{{.Description}}</option>
+{{- end}}
+</select></label>
+{{- end}}
+<button type="submit">Update</button>
+</form>
+{{- end}}
+{{- range .Pods}}
+<details class="wolfram-pod" data-pod-id="{{.ID}}"{{if .Open}} open{{end}}>
+<summary>{{.Title}}</summary>
+{{- range .Subpods}}
+<div class="wolfram-subpod">
+{{- if .Title}}
+<h4>{{.Title}}</h4>
+{{- end}}
+{{- if .Image}}
+<img src="{{.Image.URL}}" alt="{{.Image.Alt}}"{{if .Image.Width}} width="{{.Image.Width}}"{{end}}{{if .Image.Height}} height="{{.Image.Height}}"{{end}} loading="lazy">
+{{- else if .Plaintext}}
+<pre>{{.Plaintext}}</pre>
+{{- end}}
+</div>
+{{- end}}
+</details>
+{{- end}}
+</div>`)))
+
+// Render returns the answer card for the result of querying input.
+func (w Widget) Render(input string, r Result) (template.HTML, error) {
+	type subpod struct {
+		Title, Plaintext string
+		Image            *Image
+	}
+	type pod struct {
+		ID, Title string
+		Open      bool
+		Subpods   []subpod
+	}
+	data := struct {
+		Endpoint, Input string
+		Assumptions     []Assumption
+		Pods            []pod
+	}{Endpoint: w.Endpoint, Input: input}
+
+	for _, assum := range r.Assumptions {
+		if len(assum.Values) > 1 {
+			data.Assumptions = append(data.Assumptions, assum)
+		}
+	}
+	for _, p := range r.Pods {
+		out := pod{ID: p.ID, Title: p.Title, Open: w.ExpandAll || p.Primary || p.ID == "Input"}
+		for _, s := range p.Subpods {
+			sub := subpod{Title: s.Title, Plaintext: s.Plaintext}
+			if s.Image != nil && safeURL(s.Image.URL) {
+				sub.Image = s.Image
+			}
+			out.Subpods = append(out.Subpods, sub)
+		}
+		data.Pods = append(data.Pods, out)
+	}
+
+	var b strings.Builder
+	if err := widgetTemplate.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return template.HTML(b.String()), nil
+}
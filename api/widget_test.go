@@ -0,0 +1,68 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWidget_Render(t *testing.T) {
+	planet := AssumptionValue{Name: "Planet", Description: "a planet", Input: "*C.mercury-_*Planet-"}
+	element := AssumptionValue{Name: "Element", Description: "a chemical element", Input: "*C.mercury-_*Element-"}
+	r := Result{
+		Assumptions: []Assumption{
+			{Type: "Clash", Word: "mercury", Values: []AssumptionValue{planet, element}},
+			{Type: "Unit", Word: "m", Values: []AssumptionValue{{Name: "Meters"}}},
+		},
+		Pods: []Pod{
+			{ID: "Input", Title: "Input interpretation", Subpods: []Subpod{{Plaintext: "Mercury (planet)"}}},
+			{ID: "Orbit", Title: "Orbital properties", Subpods: []Subpod{{
+				Title:     "<b>",
+				Plaintext: "ignored",
+				Image:     &Image{URL: "https://wolframalpha.com/1.gif", Alt: "0.39 au", Width: 200, Height: 40},
+			}}},
+			{ID: "Evil", Title: "Evil", Subpods: []Subpod{{
+				Plaintext: "<script>",
+				Image:     &Image{URL: "javascript:alert(1)"},
+			}}},
+		},
+	}
+	html, err := Widget{Endpoint: "/answer"}.Render("mercury & sun", r)
+	assert.NoError(t, err)
+	assert.Equal(t, `<div class="wolfram-answer">
+<form class="wolfram-assumptions" method="get" action="/answer">
+<input type="hidden" name="input" value="mercury &amp; sun">
+<label>mercury <select name="assumption">
+<option value="*C.mercury-_*Planet-">a planet</option>
+<option value="*C.mercury-_*Element-">a chemical element</option>
+</select></label>
+<button type="submit">Update</button>
+</form>
+<details class="wolfram-pod" data-pod-id="Input" open>
+<summary>Input interpretation</summary>
+<div class="wolfram-subpod">
+<pre>Mercury (planet)</pre>
+</div>
+</details>
+<details class="wolfram-pod" data-pod-id="Orbit">
+<summary>Orbital properties</summary>
+<div class="wolfram-subpod">
+<h4>&lt;b&gt;</h4>
+<img src="https://wolframalpha.com/1.gif" alt="0.39 au" width="200" height="40" loading="lazy">
+</div>
+</details>
+<details class="wolfram-pod" data-pod-id="Evil">
+<summary>Evil</summary>
+<div class="wolfram-subpod">
+<pre>&lt;script&gt;</pre>
+</div>
+</details>
+</div>`, string(html))
+
+	html, err = Widget{ExpandAll: true}.Render("pi", Result{Pods: []Pod{{ID: "Result"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `<div class="wolfram-answer">
+<details class="wolfram-pod" data-pod-id="Result" open>
+<summary></summary>
+</details>
+</div>`, string(html))
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// An AnswerCache stores answers to inputs for AskWithin. Implementations must
+// be safe for concurrent use, and decide for themselves how long answers
+// stay fresh.
+type AnswerCache interface {
+	// Get returns the cached answer to the input, if any.
+	Get(input string) (answer string, ok bool)
+
+	// Set caches the answer to the input.
+	Set(input, answer string)
+}
+
+// An AskStrategy describes how AskWithin obtained an answer.
+type AskStrategy int
+
+const (
+	// The answer came from the client's Answers cache
+	CacheStrategy AskStrategy = iota
+
+	// The answer came from the Short Answers API
+	ShortAnswersStrategy
+
+	// The answer came from a plaintext-only full query
+	QueryStrategy
+)
+
+var askStrategyNames = [...]string{
+	CacheStrategy:        "cache",
+	ShortAnswersStrategy: "short answers",
+	QueryStrategy:        "query",
+}
+
+// String returns the strategy's name, like "short answers".
+func (s AskStrategy) String() string {
+	if s < 0 || int(s) >= len(askStrategyNames) {
+		return "AskStrategy(" + strconv.Itoa(int(s)) + ")"
+	}
+	return askStrategyNames[s]
+}
+
+// AskWithin is like AskContext, but returns the best answer it can obtain
+// within the budget (or before ctx's deadline, if that's sooner; a budget of
+// zero or less leaves only the deadline), degrading progressively: it tries
+// the client's Answers cache, then the Short Answers API, and then, with
+// whatever time remains, a full query for plaintext only whose server-side
+// timeouts are tightened to fit. It reports which strategy produced the
+// answer, and caches answers it fetched, keyed by the scrubbed input.
+func (c Client) AskWithin(ctx context.Context, input string, budget time.Duration) (string, AskStrategy, error) {
+	input, _ = c.scrub(input)
+	if c.Answers != nil {
+		if answer, ok := c.Answers.Get(input); ok {
+			return answer, CacheStrategy, nil
+		}
+	}
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+
+	strategy := ShortAnswersStrategy
+	answer, err := c.shortAnswer(ctx, input)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", strategy, err
+		}
+		strategy = QueryStrategy
+		if answer, err = c.tightened(ctx).AskContext(ctx, input); err != nil {
+			return "", strategy, err
+		}
+	}
+	if c.Answers != nil {
+		c.Answers.Set(input, answer)
+	}
+	return answer, strategy, nil
+}
+
+// tightened returns a copy of the client that queries for plaintext only,
+// with server-side timeouts that fit in the time left before ctx's deadline,
+// and without follow-up requests.
+func (c Client) tightened(ctx context.Context) Client {
+	c = c.Clone()
+	c.Formats = []Format{PlaintextFormat}
+	c.Async, c.DowngradeFormats, c.RecalculateBudget = false, false, 0
+	if deadline, ok := ctx.Deadline(); ok {
+		left := max(time.Until(deadline), time.Millisecond)
+		tighten := func(d *time.Duration, limit time.Duration) {
+			if *d == 0 || *d > limit {
+				*d = limit
+			}
+		}
+		tighten(&c.ParseTimeout, left/4)
+		tighten(&c.ScanTimeout, left/4)
+		tighten(&c.PodTimeout, left/2)
+		tighten(&c.FormatTimeout, left/2)
+	}
+	return c
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mapCache struct {
+	sync.Mutex
+	answers map[string]string
+}
+
+func (m *mapCache) Get(input string) (string, bool) {
+	m.Lock()
+	defer m.Unlock()
+	answer, ok := m.answers[input]
+	return answer, ok
+}
+
+func (m *mapCache) Set(input, answer string) {
+	m.Lock()
+	defer m.Unlock()
+	m.answers[input] = answer
+}
+
+func TestClient_AskWithin(t *testing.T) {
+	params := serve(t, resultXML)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("i") == "10 feet in meters" {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		w.Write([]byte("3.14159"))
+	}))
+	defer server.Close()
+	orig := shortAnswersURL
+	shortAnswersURL = server.URL
+	defer func() { shortAnswersURL = orig }()
+
+	cache := &mapCache{answers: map[string]string{}}
+	c := Client{Answers: cache, Formats: []Format{PlaintextFormat, ImageF}, PodTimeout: time.Minute}
+
+	answer, strategy, err := c.AskWithin(context.Background(), "pi", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14159", answer)
+	assert.Equal(t, ShortAnswersStrategy, strategy)
+	assert.Nil(t, *params)
+
+	answer, strategy, err = c.AskWithin(context.Background(), "pi", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14159", answer)
+	assert.Equal(t, CacheStrategy, strategy)
+
+	answer, strategy, err = c.AskWithin(context.Background(), "10 feet in meters", 8*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.048 meters", answer)
+	assert.Equal(t, QueryStrategy, strategy)
+	assert.Equal(t, "plaintext", params.Get("format"))
+	scan, _ := strconv.ParseFloat(params.Get("scantimeout"), 64)
+	pod, _ := strconv.ParseFloat(params.Get("podtimeout"), 64)
+	assert.True(t, scan > 1 && scan <= 2, "scantimeout %v", scan)
+	assert.True(t, pod > 2 && pod <= 4, "podtimeout %v", pod)
+	assert.Equal(t, map[string]string{"pi": "3.14159", "10 feet in meters": "3.048 meters"}, cache.answers)
+}
+
+func TestClient_AskWithin_endpoint(t *testing.T) {
+	var paths, inputs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		inputs = append(inputs, r.URL.Query().Get("i"))
+		if r.URL.Query().Get("appid") == "BAD" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("Error 1: Invalid appid"))
+			return
+		}
+		w.Write([]byte("42"))
+	}))
+	defer server.Close()
+
+	cache := &mapCache{answers: map[string]string{}}
+	pool := NewAppIDPool(RoundRobin, "BAD", "GOOD")
+	var statuses []int
+	c := Client{
+		Endpoint:   server.URL + "/proxy/v2/query",
+		AppIDs:     pool,
+		Answers:    cache,
+		Scrubbers:  []Scrubber{EmailScrubber},
+		OnResponse: func(status int, body []byte) { statuses = append(statuses, status) },
+	}
+	_, err := c.shortAnswer(context.Background(), "6*7")
+	assert.Equal(t, &Error{Code: 1, Message: "Invalid appid"}, err)
+	assert.Equal(t, 1, pool.Failures("BAD"))
+
+	answer, strategy, err := c.AskWithin(context.Background(), "mail bob@example.com 6*7", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", answer)
+	assert.Equal(t, ShortAnswersStrategy, strategy)
+	assert.Equal(t, []string{"/proxy/v1/result", "/proxy/v1/result"}, paths)
+	assert.Equal(t, []string{"6*7", "mail 6*7"}, inputs)
+	assert.Equal(t, []int{http.StatusForbidden, http.StatusOK}, statuses)
+	assert.Equal(t, map[string]string{"mail 6*7": "42"}, cache.answers)
+}
+
+func TestClient_shortAnswersEndpoint(t *testing.T) {
+	assert.Equal(t, shortAnswersURL, Client{}.shortAnswersEndpoint())
+	assert.Equal(t, "https://example.com/wolfram/v1/result", Client{Endpoint: "https://example.com/wolfram/v2/query"}.shortAnswersEndpoint())
+	assert.Equal(t, "http://localhost:8080/v1/result", Client{Endpoint: "http://localhost:8080/query"}.shortAnswersEndpoint())
+}
+
+func TestAskStrategy_String(t *testing.T) {
+	assert.Equal(t, "short answers", ShortAnswersStrategy.String())
+	assert.Equal(t, "AskStrategy(7)", AskStrategy(7).String())
+}
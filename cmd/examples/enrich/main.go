@@ -0,0 +1,62 @@
+// Command enrich adds a column of Wolfram Alpha answers to a CSV file. Each
+// row's question is built by substituting a column's value into a template.
+//
+// Usage:
+//
+//	WOLFRAM_APP_ID=... enrich -column 0 -template "population of %s" < in.csv > out.csv
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"github.com/hollingberry/wolfram/api"
+	"io"
+	"log"
+	"os"
+)
+
+func main() {
+	column := flag.Int("column", 0, "index of the column to substitute into the template")
+	template := flag.String("template", "%s", "question template, with %s for the column value")
+	header := flag.Bool("header", true, "whether the first row is a header")
+	flag.Parse()
+
+	client := api.NewClient(os.Getenv("WOLFRAM_APP_ID"))
+	if err := enrich(client, os.Stdin, os.Stdout, *column, *template, *header); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// enrich copies the CSV from in to out, adding a column of answers to the
+// questions built by substituting the given column's values into the
+// template. Rows that can't be answered get an empty answer, and the problem
+// is logged.
+func enrich(client api.Client, in io.Reader, out io.Writer, column int, template string, header bool) error {
+	r, w := csv.NewReader(in), csv.NewWriter(out)
+	defer w.Flush()
+
+	for i := 0; ; i++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var answer string
+		switch {
+		case i == 0 && header:
+			answer = "answer"
+		case column >= len(row):
+			log.Printf("row %d: no column %d", i+1, column)
+		default:
+			if answer, err = client.Ask(fmt.Sprintf(template, row[column])); err != nil {
+				log.Printf("row %d: %v", i+1, err)
+			}
+		}
+		if err := w.Write(append(row, answer)); err != nil {
+			return err
+		}
+	}
+}
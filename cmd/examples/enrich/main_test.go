@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/hollingberry/wolfram/api"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEnrich(t *testing.T) {
+	t.Setenv("WOLFRAM_APP_ID", "")
+	t.Setenv("WOLFRAM_ENDPOINT", "")
+	t.Setenv("WOLFRAM_TIMEOUT", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		answer := map[string]string{
+			"population of France":  "67.8 million people",
+			"population of Germany": "83.2 million people",
+		}[r.URL.Query().Get("input")]
+		if answer == "" {
+			w.Write([]byte(`<queryresult success="false"/>`))
+			return
+		}
+		w.Write([]byte(`<queryresult success="true">
+		                  <pod id="Input"><subpod><plaintext>input</plaintext></subpod></pod>
+		                  <pod id="Result" primary="true"><subpod><plaintext>` + answer + `</plaintext></subpod></pod>
+		                </queryresult>`))
+	}))
+	defer server.Close()
+
+	in := "country,code\nFrance,FR\nGermany,DE\nAtlantis,AT\n"
+	var out strings.Builder
+	err := enrich(api.Client{Endpoint: server.URL}, strings.NewReader(in), &out, 0, "population of %s", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "country,code,answer\n"+
+		"France,FR,67.8 million people\n"+
+		"Germany,DE,83.2 million people\n"+
+		"Atlantis,AT,\n", out.String())
+
+	out.Reset()
+	err = enrich(api.Client{Endpoint: server.URL}, strings.NewReader("France\n"), &out, 3, "%s", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "France,\n", out.String())
+}
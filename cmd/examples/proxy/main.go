@@ -0,0 +1,134 @@
+// Command proxy forwards queries to the Wolfram Alpha API, adding the AppID,
+// so that browser (js/wasm) builds can query through a same-origin endpoint
+// without shipping the AppID to the browser. Point api.Client.Endpoint at
+// the proxy's /v2/query path.
+//
+// Successful responses carry an ETag derived from the answer (a hash of each
+// pod's ID, title, and plaintext, leaving out the image URLs and timings that
+// differ on every response) and a Cache-Control header allowing public
+// caching for -max-age. Every request is forwarded to Wolfram Alpha, but a
+// request whose If-None-Match matches the fresh answer's ETag gets 304 Not
+// Modified without the body, so CDNs in front of the proxy can revalidate
+// cheaply while time-sensitive answers still change. Failed queries are
+// never cached.
+//
+// Usage:
+//
+//	WOLFRAM_APP_ID=... proxy -addr :8080 -max-age 1h
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"github.com/hollingberry/wolfram/api"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	maxAge := flag.Duration("max-age", time.Hour, "how long caches may reuse responses (0 disables caching)")
+	flag.Parse()
+
+	upstream := &url.URL{Scheme: "https", Host: "api.wolframalpha.com", Path: "/v2/query"}
+	http.Handle("/v2/query", newProxy(upstream, os.Getenv("WOLFRAM_APP_ID"), *maxAge))
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// newProxy returns a handler forwarding queries to the upstream endpoint with
+// the AppID, setting caching headers on the responses.
+func newProxy(upstream *url.URL, appID string, maxAge time.Duration) http.Handler {
+	return &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			q := r.In.URL.Query()
+			q.Set("appid", appID)
+			r.Out.URL.Scheme = upstream.Scheme
+			r.Out.URL.Host = upstream.Host
+			r.Out.URL.Path = upstream.Path
+			r.Out.URL.RawQuery = q.Encode()
+			r.Out.Host = ""
+			// Let the transport negotiate compression, so that
+			// cacheHeaders sees the decompressed body.
+			r.Out.Header.Del("Accept-Encoding")
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			return cacheHeaders(resp, maxAge)
+		},
+	}
+}
+
+// etag returns the ETag for a result, which changes whenever its pods'
+// content does.
+func etag(result api.Result) string {
+	h := sha256.New()
+	for _, pod := range result.Pods {
+		fmt.Fprintf(h, "%q %q\n", pod.ID, pod.Title)
+		for _, subpod := range pod.Subpods {
+			fmt.Fprintf(h, "\t%q %q\n", subpod.Title, subpod.Plaintext)
+		}
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:8]) + `"`
+}
+
+// cacheControl returns the Cache-Control header allowing public caching for
+// maxAge.
+func cacheControl(maxAge time.Duration) string {
+	return "public, max-age=" + strconv.Itoa(int(maxAge.Seconds()))
+}
+
+// cacheHeaders sets the ETag and Cache-Control headers on a response for a
+// successful query, turning it into 304 Not Modified if the request's
+// If-None-Match matches, and forbids caching any other response.
+func cacheHeaders(resp *http.Response, maxAge time.Duration) error {
+	if resp.StatusCode != http.StatusOK || maxAge <= 0 {
+		resp.Header.Set("Cache-Control", "no-store")
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var result api.Result
+	if err := xml.Unmarshal(body, &result); err != nil || !result.Succeeded || result.Errored {
+		resp.Header.Set("Cache-Control", "no-store")
+		return nil
+	}
+	tag := etag(result)
+	resp.Header.Set("ETag", tag)
+	resp.Header.Set("Cache-Control", cacheControl(maxAge))
+	resp.Header.Add("Vary", "Accept-Encoding")
+	if etagMatches(resp.Request.Header.Get("If-None-Match"), tag) {
+		resp.StatusCode, resp.Status = http.StatusNotModified, "304 Not Modified"
+		resp.Body, resp.ContentLength = http.NoBody, 0
+		resp.Header.Del("Content-Length")
+		resp.Header.Del("Content-Type")
+	}
+	return nil
+}
+
+// etagMatches reports whether an If-None-Match header lists the ETag. The
+// wildcard "*" isn't a match, since it only asks whether a representation
+// exists, and a query's answer may not.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"github.com/hollingberry/wolfram/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestProxy(t *testing.T) {
+	var requests []url.Values
+	answer := "3.14159"
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query())
+		if r.URL.Query().Get("input") == "fjqpwoeiruty" {
+			w.Write([]byte(`<queryresult success="false" error="false"/>`))
+			return
+		}
+		w.Write([]byte(`<queryresult success="true" error="false" timing="` + strconv.Itoa(len(requests)) + `">
+		                  <pod id="Result" title="Result"><subpod><plaintext>` + answer + `</plaintext></subpod></pod>
+		                </queryresult>`))
+	}))
+	defer upstream.Close()
+	u, _ := url.Parse(upstream.URL + "/v2/query")
+	proxy := httptest.NewServer(newProxy(u, "SECRET", time.Hour))
+	defer proxy.Close()
+
+	get := func(query, ifNoneMatch string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, proxy.URL+"/v2/query?"+query, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := get("input=pi&format=plaintext", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=3600", resp.Header.Get("Cache-Control"))
+	etag := resp.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "SECRET", requests[0].Get("appid"))
+
+	// Revalidating an unchanged answer, even with differing timings, is
+	// checked upstream and not modified.
+	resp = get("format=plaintext&input=pi", etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+	assert.Len(t, requests, 2)
+
+	// A changed answer is sent in full with a new ETag.
+	answer = "3.1415926"
+	resp = get("input=pi&format=plaintext", etag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+	resp = get("input=pi&format=plaintext", "*")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = get("input=fjqpwoeiruty", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	assert.Empty(t, resp.Header.Get("ETag"))
+}
+
+func TestProxy_client(t *testing.T) {
+	t.Setenv("WOLFRAM_APP_ID", "")
+	t.Setenv("WOLFRAM_ENDPOINT", "")
+	t.Setenv("WOLFRAM_TIMEOUT", "")
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "SECRET", r.URL.Query().Get("appid"))
+		w.Write([]byte(`<queryresult success="true">
+		                  <pod id="Input"><subpod><plaintext>pi</plaintext></subpod></pod>
+		                  <pod id="Result" primary="true"><subpod><plaintext>3.14159</plaintext></subpod></pod>
+		                </queryresult>`))
+	}))
+	defer upstream.Close()
+	u, _ := url.Parse(upstream.URL + "/v2/query")
+	proxy := httptest.NewServer(newProxy(u, "SECRET", time.Hour))
+	defer proxy.Close()
+
+	answer, err := api.Client{Endpoint: proxy.URL + "/v2/query"}.Ask("pi")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14159", answer)
+}
@@ -0,0 +1,47 @@
+// Command repl answers questions typed at a prompt, one per line.
+//
+// Usage:
+//
+//	WOLFRAM_APP_ID=... repl
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hollingberry/wolfram/api"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+func main() {
+	client := api.NewClient(os.Getenv("WOLFRAM_APP_ID"))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	repl(ctx, client, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// repl answers each line of input, writing prompts and answers to out and
+// errors to errOut.
+func repl(ctx context.Context, client api.Client, in io.Reader, out, errOut io.Writer) {
+	scanner := bufio.NewScanner(in)
+	for fmt.Fprint(out, "> "); scanner.Scan(); fmt.Fprint(out, "> ") {
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+		answer, err := client.AskContext(ctx, input)
+		var noContent *api.NoContentError
+		switch {
+		case errors.As(err, &noContent):
+			fmt.Fprintln(out, noContent.Guidance())
+		case err != nil:
+			fmt.Fprintln(errOut, err)
+		default:
+			fmt.Fprintln(out, answer)
+		}
+	}
+}
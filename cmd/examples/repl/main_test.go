@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"github.com/hollingberry/wolfram/api"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRepl(t *testing.T) {
+	t.Setenv("WOLFRAM_APP_ID", "")
+	t.Setenv("WOLFRAM_ENDPOINT", "")
+	t.Setenv("WOLFRAM_TIMEOUT", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("input") {
+		case "10 feet in meters":
+			w.Write([]byte(`<queryresult success="true">
+			                  <pod id="Input"><subpod><plaintext>10 feet in meters</plaintext></subpod></pod>
+			                  <pod id="Result" primary="true"><subpod><plaintext>3.048 meters</plaintext></subpod></pod>
+			                </queryresult>`))
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`<queryresult success="false"/>`))
+		}
+	}))
+	defer server.Close()
+
+	var out, errOut strings.Builder
+	in := strings.NewReader("10 feet in meters\n\nbroken\n")
+	repl(context.Background(), api.Client{Endpoint: server.URL}, in, &out, &errOut)
+	assert.Equal(t, "> 3.048 meters\n> > > ", out.String())
+	assert.Contains(t, errOut.String(), "500")
+}
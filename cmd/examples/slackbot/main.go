@@ -0,0 +1,99 @@
+// Command slackbot answers Slack slash commands, like "/wolfram 10 feet in
+// meters", with Wolfram Alpha. Configure the slash command's request URL to
+// point at the bot's /slack path. Requests are verified with the app's
+// signing secret, and answers are posted to the channel; guidance for
+// questions without an answer is only shown to the asker.
+//
+// Slack expects a response within three seconds, so queries time out before
+// then.
+//
+// Usage:
+//
+//	WOLFRAM_APP_ID=... SLACK_SIGNING_SECRET=... slackbot -addr :8080
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"github.com/hollingberry/wolfram/api"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	client := api.NewClient(os.Getenv("WOLFRAM_APP_ID"))
+	client.Timeout = 2500 * time.Millisecond
+	http.Handle("/slack", newHandler(client, os.Getenv("SLACK_SIGNING_SECRET")))
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// A message is a slash command response.
+type message struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// newHandler returns a handler answering slash commands verified with the
+// signing secret.
+func newHandler(client api.Client, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !verify(r.Header, body, secret, time.Now()) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		msg := message{ResponseType: "ephemeral"}
+		answer, err := client.AskContext(r.Context(), strings.TrimSpace(form.Get("text")))
+		var noContent *api.NoContentError
+		switch {
+		case errors.As(err, &noContent):
+			msg.Text = noContent.Guidance()
+		case err != nil:
+			log.Print(err)
+			msg.Text = "Sorry, Wolfram Alpha couldn't be reached."
+		default:
+			msg.ResponseType, msg.Text = "in_channel", answer
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(msg)
+	})
+}
+
+// verify reports whether a request body carries a valid Slack signature made
+// with the secret within the last five minutes. See
+// https://api.slack.com/authentication/verifying-requests-from-slack.
+func verify(header http.Header, body []byte, secret string, now time.Time) bool {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(ts, 0)).Abs() > 5*time.Minute {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	io.WriteString(mac, "v0:"+timestamp+":")
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature")))
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/hollingberry/wolfram/api"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// command sends a slash command with the given text to the bot, signed with
+// the secret, and returns the response status and message.
+func command(t *testing.T, bot *httptest.Server, text, secret string) (int, message) {
+	body := url.Values{"command": {"/wolfram"}, "text": {text}}.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	req, _ := http.NewRequest(http.MethodPost, bot.URL, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		return 0, message{}
+	}
+	defer resp.Body.Close()
+	var msg message
+	json.NewDecoder(resp.Body).Decode(&msg)
+	return resp.StatusCode, msg
+}
+
+func TestHandler(t *testing.T) {
+	t.Setenv("WOLFRAM_APP_ID", "")
+	t.Setenv("WOLFRAM_ENDPOINT", "")
+	t.Setenv("WOLFRAM_TIMEOUT", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("input") != "10 feet in meters" {
+			w.Write([]byte(`<queryresult success="false"/>`))
+			return
+		}
+		w.Write([]byte(`<queryresult success="true">
+		                  <pod id="Input"><subpod><plaintext>10 feet in meters</plaintext></subpod></pod>
+		                  <pod id="Result" primary="true"><subpod><plaintext>3.048 meters</plaintext></subpod></pod>
+		                </queryresult>`))
+	}))
+	defer server.Close()
+	bot := httptest.NewServer(newHandler(api.Client{Endpoint: server.URL}, "SECRET"))
+	defer bot.Close()
+
+	status, msg := command(t, bot, "10 feet in meters", "SECRET")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, message{ResponseType: "in_channel", Text: "3.048 meters"}, msg)
+
+	status, msg = command(t, bot, "fjqpwoeiruty", "SECRET")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ephemeral", msg.ResponseType)
+	assert.NotEmpty(t, msg.Text)
+
+	status, _ = command(t, bot, "10 feet in meters", "WRONG")
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte("text=pi")
+	mac := hmac.New(sha256.New, []byte("SECRET"))
+	mac.Write([]byte("v0:1700000000:text=pi"))
+	header := http.Header{
+		"X-Slack-Request-Timestamp": {"1700000000"},
+		"X-Slack-Signature":         {"v0=" + hex.EncodeToString(mac.Sum(nil))},
+	}
+	assert.True(t, verify(header, body, "SECRET", now))
+	assert.False(t, verify(header, []byte("text=e"), "SECRET", now))
+	assert.False(t, verify(header, body, "SECRET", now.Add(10*time.Minute)))
+}
@@ -0,0 +1,181 @@
+// Command schemasnap detects changes in the Full Results API's response
+// schema. It runs a fixed panel of queries, collects the names of the XML
+// elements and attributes in the responses, and compares them with a
+// committed snapshot, so new elements that the api package's structs don't
+// cover show up in a report instead of being silently dropped.
+//
+// It exits with status 1 if the responses contain names missing from the
+// snapshot. Names in the snapshot that no response contained are reported
+// too, but may just be absent from this run's answers.
+//
+// Usage:
+//
+//	WOLFRAM_APP_ID=... schemasnap [-snapshot api/testdata/schema.txt] [-update]
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"github.com/hollingberry/wolfram/api"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The query panel, chosen to cover a wide range of pod types, assumptions,
+// warnings, and errors
+var panel = []string{
+	"pi",
+	"10 feet in meters",
+	"mercury",
+	"weather in Chicago",
+	"population of France vs Germany",
+	"integrate x^2 sin x",
+	"plot sin x",
+	"largest countries by area",
+	"Eiffel tower",
+	"July 4, 1776",
+	"price of gold",
+	"ibuprofen",
+	"C major chord",
+	"wie hoch ist der Eiffelturm",
+	"pai",
+	"fjqpwoeiruty",
+}
+
+func main() {
+	snapshot := flag.String("snapshot", "api/testdata/schema.txt", "path of the committed snapshot")
+	update := flag.Bool("update", false, "rewrite the snapshot with the names seen instead of comparing")
+	flag.Parse()
+
+	seen := make(map[string]bool)
+	client := api.NewClient(os.Getenv("WOLFRAM_APP_ID"))
+	client.Formats = []api.Format{
+		api.PlaintextFormat, api.ImageF, api.MathematicaInputFormat,
+		api.MathematicaOutputFormat, api.CellFormat, api.MathMLFormat,
+		api.ImageMapFormat, api.SoundFormat, api.WavFormat,
+	}
+	client.Reinterpret = api.ToggleOn
+	client.Translation = true
+	client.OnResponse = func(status int, body []byte) {
+		if err := collect(body, seen); err != nil {
+			log.Printf("decoding response: %v", err)
+		}
+	}
+	for _, input := range panel {
+		if _, err := client.Query(input); err != nil {
+			log.Printf("%s: %v", input, err)
+		}
+	}
+	if len(seen) == 0 {
+		log.Fatal("no responses; check WOLFRAM_APP_ID")
+	}
+
+	if *update {
+		if err := write(*snapshot, seen); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	known, err := read(*snapshot)
+	if err != nil {
+		log.Fatal(err)
+	}
+	added, missing := diff(seen, known)
+	for _, name := range added {
+		fmt.Println("+", name)
+	}
+	for _, name := range missing {
+		fmt.Println("-", name)
+	}
+	if len(added) > 0 {
+		os.Exit(1)
+	}
+}
+
+// collect adds the names of the elements in an XML response to seen, as
+// "element", and of their attributes, as "element@attribute". MathML content
+// is skipped, since its elements are passed through rather than decoded.
+func collect(body []byte, seen map[string]bool) error {
+	d := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		seen[start.Name.Local] = true
+		for _, attr := range start.Attr {
+			seen[start.Name.Local+"@"+attr.Name.Local] = true
+		}
+		if start.Name.Local == "mathml" {
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// diff returns the names seen but not known, and known but not seen, sorted.
+func diff(seen, known map[string]bool) (added, missing []string) {
+	for name := range seen {
+		if !known[name] {
+			added = append(added, name)
+		}
+	}
+	for name := range known {
+		if !seen[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(missing)
+	return added, missing
+}
+
+// read reads a snapshot: one name per line, ignoring blank lines and
+// comments starting with "#".
+func read(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	names := make(map[string]bool)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			names[line] = true
+		}
+	}
+	return names, s.Err()
+}
+
+// write writes the names to a snapshot, sorted.
+func write(path string, names map[string]bool) error {
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	b.WriteString("# Element and attribute names seen in Full Results API responses.\n")
+	b.WriteString("# Regenerate with: go run ./cmd/schemasnap -update\n")
+	for _, name := range sorted {
+		b.WriteString(name + "\n")
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
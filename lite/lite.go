@@ -0,0 +1,252 @@
+// Package lite provides a minimal, plaintext-only client for the Wolfram
+// Alpha API, for TinyGo, embedded devices, and other constrained
+// environments that ask simple questions (weather, conversions) over slow
+// links.
+//
+// Unlike package api, it requests only plaintext results and parses them
+// with a small hand-rolled scanner instead of encoding/xml, whose reflection
+// is costly (or unsupported) on such targets. It depends only on a handful
+// of standard library packages.
+package lite
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The default Full Results API endpoint
+var queryURL = "https://api.wolframalpha.com/v2/query"
+
+// ErrNoAnswer occurs when Ask gets a result with no answer pod.
+var ErrNoAnswer = errors.New("lite: no answer")
+
+// A Client sends plaintext-only queries to Wolfram Alpha.
+type Client struct {
+	// The AppID for your application
+	AppID string
+
+	// The URL of the Full Results API endpoint, or of a proxy for it. If empty,
+	// Wolfram Alpha's own endpoint is used.
+	Endpoint string
+
+	// The HTTP client used to make requests. If nil, http.DefaultClient is
+	// used.
+	HTTPClient *http.Client
+}
+
+// A Pod holds the plaintext of one category of result.
+type Pod struct {
+	// The internal identifier for the pod type
+	ID string
+
+	// The pod title
+	Title string
+
+	// Whether the pod is the query's primary pod
+	Primary bool
+
+	// The plaintext of each of the pod's subpods
+	Plaintext []string
+}
+
+// Query sends the input to Wolfram Alpha and returns the result's pods.
+func (c Client) Query(input string) ([]Pod, error) {
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = queryURL
+	}
+	v := url.Values{"appid": {c.AppID}, "input": {input}, "format": {"plaintext"}}
+
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Get(endpoint + "?" + v.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("lite: unexpected response status " + resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parse(string(body))
+}
+
+// Ask sends the input to Wolfram Alpha and returns the plaintext of the
+// answer: the primary pod's first subpod, or if no pod is marked primary, the
+// first pod after the "Input interpretation" pod.
+func (c Client) Ask(input string) (string, error) {
+	pods, err := c.Query(input)
+	if err != nil {
+		return "", err
+	}
+	var answer *Pod
+	for i, pod := range pods {
+		if pod.Primary {
+			answer = &pods[i]
+			break
+		}
+		if answer == nil && pod.ID != "Input" && len(pod.Plaintext) > 0 {
+			answer = &pods[i]
+		}
+	}
+	if answer == nil || len(answer.Plaintext) == 0 {
+		return "", ErrNoAnswer
+	}
+	return answer.Plaintext[0], nil
+}
+
+// parse scans a queryresult document for pods and their plaintext.
+func parse(doc string) ([]Pod, error) {
+	root, _, ok := nextTag(doc, "queryresult")
+	if !ok {
+		return nil, errors.New("lite: malformed response")
+	}
+	if attr(root, "error") == "true" {
+		msg := "unknown error"
+		if i := strings.Index(doc, "<msg>"); i >= 0 {
+			if j := strings.Index(doc[i:], "</msg>"); j >= 0 {
+				msg = unescape(doc[i+len("<msg>") : i+j])
+			}
+		}
+		return nil, errors.New("lite: " + msg)
+	}
+
+	var pods []Pod
+	for {
+		tag, rest, ok := nextTag(doc, "pod")
+		if !ok {
+			return pods, nil
+		}
+		end := strings.Index(rest, "</pod>")
+		if end < 0 || strings.HasSuffix(tag, "/") {
+			end = 0
+		}
+		pod := Pod{
+			ID:      attr(tag, "id"),
+			Title:   attr(tag, "title"),
+			Primary: attr(tag, "primary") == "true",
+		}
+		body := rest[:end]
+		for {
+			i := strings.Index(body, "<plaintext")
+			if i < 0 {
+				break
+			}
+			body = body[i+len("<plaintext"):]
+			if strings.HasPrefix(body, "/>") {
+				pod.Plaintext = append(pod.Plaintext, "")
+				continue
+			}
+			j := strings.Index(body, "</plaintext>")
+			if j < 0 || !strings.HasPrefix(body, ">") {
+				return nil, errors.New("lite: malformed plaintext element")
+			}
+			pod.Plaintext = append(pod.Plaintext, unescape(body[1:j]))
+			body = body[j:]
+		}
+		pods = append(pods, pod)
+		doc = rest[end:]
+	}
+}
+
+// nextTag finds the next start tag with the given name, returning the text
+// inside the tag (its attributes) and the rest of the document after it.
+func nextTag(doc, name string) (tag, rest string, ok bool) {
+	for {
+		i := strings.Index(doc, "<"+name)
+		if i < 0 {
+			return "", "", false
+		}
+		doc = doc[i+1+len(name):]
+		if doc != "" && (doc[0] == ' ' || doc[0] == '>' || doc[0] == '/' || doc[0] == '\n' || doc[0] == '\t' || doc[0] == '\r') {
+			j := strings.Index(doc, ">")
+			if j < 0 {
+				return "", "", false
+			}
+			return doc[:j], doc[j+1:], true
+		}
+	}
+}
+
+// attr returns the value of the named attribute in the text of a tag.
+func attr(tag, name string) string {
+	for {
+		i := strings.Index(tag, name+"=")
+		if i < 0 {
+			return ""
+		}
+		before := i == 0 || tag[i-1] == ' ' || tag[i-1] == '\n' || tag[i-1] == '\t' || tag[i-1] == '\r'
+		tag = tag[i+len(name)+1:]
+		if tag == "" || tag[0] != '"' && tag[0] != '\'' {
+			continue
+		}
+		j := strings.IndexByte(tag[1:], tag[0])
+		if j < 0 {
+			return ""
+		}
+		if before {
+			return unescape(tag[1 : j+1])
+		}
+		tag = tag[j+2:]
+	}
+}
+
+// unescape replaces XML character and entity references with the characters
+// they stand for.
+func unescape(s string) string {
+	if !strings.Contains(s, "&") {
+		return s
+	}
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(s, '&')
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		j := strings.IndexByte(s, ';')
+		if j < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		switch ref := s[1:j]; ref {
+		case "amp":
+			b.WriteByte('&')
+		case "lt":
+			b.WriteByte('<')
+		case "gt":
+			b.WriteByte('>')
+		case "quot":
+			b.WriteByte('"')
+		case "apos":
+			b.WriteByte('\'')
+		default:
+			var n uint64
+			var err error
+			if strings.HasPrefix(ref, "#x") {
+				n, err = strconv.ParseUint(ref[2:], 16, 32)
+			} else if strings.HasPrefix(ref, "#") {
+				n, err = strconv.ParseUint(ref[1:], 10, 32)
+			} else {
+				err = errors.New("unknown entity")
+			}
+			if err != nil {
+				b.WriteString(s[:j+1])
+			} else {
+				b.WriteRune(rune(n))
+			}
+		}
+		s = s[j+1:]
+	}
+}
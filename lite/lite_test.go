@@ -0,0 +1,63 @@
+package lite
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+const resultXML = `<?xml version='1.0' encoding='UTF-8'?>
+  <queryresult success='true' error='false' numpods='3'>
+    <pod title='Input interpretation' scanner='Identity' id='Input' position='100'>
+      <subpod title=''>
+        <plaintext>convert 10 feet to meters</plaintext>
+      </subpod>
+    </pod>
+    <pod title='Result' scanner='Identity' id='Result' position='200' primary='true'>
+      <subpod title=''>
+        <plaintext>3.048 meters</plaintext>
+      </subpod>
+    </pod>
+    <pod title='Additional conversions' id='AdditionalConversion' position='300'>
+      <subpod title=''><plaintext>3.333 yards &amp; 120 &quot;inches&quot; &#960;</plaintext></subpod>
+      <subpod title=''><plaintext/></subpod>
+    </pod>
+    <pod title='Empty' id='Empty' position='400'/>
+  </queryresult>`
+
+func TestParse(t *testing.T) {
+	pods, err := parse(resultXML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Pod{
+		{ID: "Input", Title: "Input interpretation", Plaintext: []string{"convert 10 feet to meters"}},
+		{ID: "Result", Title: "Result", Primary: true, Plaintext: []string{"3.048 meters"}},
+		{ID: "AdditionalConversion", Title: "Additional conversions", Plaintext: []string{`3.333 yards & 120 "inches" π`, ""}},
+		{ID: "Empty", Title: "Empty"},
+	}, pods)
+
+	_, err = parse(`<queryresult success='false' error='true'><error><code>1</code><msg>Invalid appid</msg></error></queryresult>`)
+	assert.EqualError(t, err, "lite: Invalid appid")
+
+	_, err = parse(`<html>Bad gateway</html>`)
+	assert.Error(t, err)
+}
+
+func TestClient_Ask(t *testing.T) {
+	var params url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params = r.URL.Query()
+		w.Write([]byte(resultXML))
+	}))
+	defer server.Close()
+
+	answer, err := Client{AppID: "APPID", Endpoint: server.URL}.Ask("10 feet in meters")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.048 meters", answer)
+	assert.Equal(t, url.Values{
+		"appid":  {"APPID"},
+		"input":  {"10 feet in meters"},
+		"format": {"plaintext"},
+	}, params)
+}